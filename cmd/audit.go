@@ -0,0 +1,204 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditOutputPath    string
+	auditShowPasswords bool
+)
+
+// auditColumns maps the accepted header names of the profiles CSV to the
+// column they fill.
+var auditColumns = map[string]string{
+	"id":            "id",
+	"name":          "id",
+	"firstname":     "firstname",
+	"first_name":    "firstname",
+	"lastname":      "lastname",
+	"last_name":     "lastname",
+	"birthday":      "birthday",
+	"words":         "words",
+	"related_words": "words",
+	"password":      "password",
+}
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit <profiles.csv>",
+	Short: "Check whether known passwords are guessable from personal information",
+	Long: `Audit reads a CSV of profiles and their known or test passwords and reports,
+for every row, whether the password is generated from the profile, at which
+rank in the wordlist and how it is derived.
+
+The CSV needs a header row with the columns firstname, lastname and password,
+and optionally id, birthday (DD/MM/YYYY or similar) and words (separated by
+commas). The generation options such as --leet and --caps apply to every row.
+
+Passwords are left out of the report unless --show-passwords is given, and so
+are the words and leet substitutions they are derived from: the derivation
+then only names the profile fields and transforms.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAudit(args[0])
+	},
+}
+
+func runAudit(profilesPath string) {
+	file, err := os.Open(profilesPath)
+	if err != nil {
+		fmt.Printf("Error opening profiles: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		fmt.Printf("Error reading profiles: %v\n", err)
+		os.Exit(1)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if column, ok := auditColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+	for _, required := range []string{"firstname", "lastname", "password"} {
+		if _, ok := columns[required]; !ok {
+			fmt.Printf("Error: profiles CSV must have a %s column\n", required)
+			os.Exit(1)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if auditOutputPath != "" {
+		reportFile, err := os.Create(auditOutputPath)
+		if err != nil {
+			fmt.Printf("Error creating report: %v\n", err)
+			os.Exit(1)
+		}
+		defer reportFile.Close()
+		out = reportFile
+	}
+
+	report := csv.NewWriter(out)
+	reportHeader := []string{"id", "firstname", "lastname", "status", "rank", "derivation", "closest", "distance"}
+	if auditShowPasswords {
+		reportHeader = append(reportHeader, "password")
+	}
+	report.Write(reportHeader)
+
+	rows, guessable := 0, 0
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading profiles: %v\n", err)
+			os.Exit(1)
+		}
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		id := field("id")
+		if id == "" {
+			id = strconv.Itoa(line - 1)
+		}
+		first, last, password := field("firstname"), field("lastname"), field("password")
+		if first == "" || last == "" || password == "" {
+			fmt.Fprintf(os.Stderr, "Skipping line %d: firstname, lastname and password are required\n", line)
+			continue
+		}
+
+		opts, err := buildOptions(first, last, field("birthday"), field("words"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping line %d: %v\n", line, err)
+			continue
+		}
+		opts.Workers = workers
+		e, err := generator.Explain(opts, password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error auditing line %d: %v\n", line, err)
+			os.Exit(1)
+		}
+
+		rows++
+		status, rank, closest, distance := "not guessable", "", e.Candidate, strconv.Itoa(e.Distance)
+		switch {
+		case e.Found && !e.InLengthRange:
+			status = "filtered by length"
+			closest, distance = "", ""
+		case e.Found && !e.StrongEnough:
+			status = "filtered by strength"
+			closest, distance = "", ""
+		case e.Found:
+			status, rank = "guessable", strconv.FormatInt(e.Rank, 10)
+			closest, distance = "", ""
+			guessable++
+		}
+		derivation := e.Derivation
+		if !auditShowPasswords {
+			derivation, closest = e.Outline, ""
+		}
+
+		row := []string{id, first, last, status, rank, derivation, closest, distance}
+		if auditShowPasswords {
+			row = append(row, password)
+		}
+		report.Write(row)
+	}
+	report.Flush()
+	if err := report.Error(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d of %d passwords are guessable from the profile\n", guessable, rows)
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	addVariationFlags(auditCmd.Flags())
+	auditCmd.Flags().StringVarP(&auditOutputPath, "output", "o", "", "Report file path (default stdout)")
+	auditCmd.Flags().BoolVar(&auditShowPasswords, "show-passwords", false, "Include the passwords, closest candidates and full derivations in the report")
+	auditCmd.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+}
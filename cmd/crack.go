@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/crack"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hashType    string
+	potfilePath string
+)
+
+// crackCmd represents the crack command
+var crackCmd = &cobra.Command{
+	Use:   "crack <hashfile>",
+	Short: "Check generated passwords against a local hash list",
+	Long: `Crack generates the wordlist for a profile and, instead of writing it to a
+file, hashes every candidate and compares it against a local list of hashes
+(one per line). Supported hashes are MD5, SHA-1, SHA-256, SHA-512, NTLM and
+bcrypt; by default the type of every hash is detected from its shape.
+
+Cracked hashes are printed in potfile format (hash:password) and can be
+appended to a potfile. Everything runs offline.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runCrack(cmd, args[0])
+	},
+}
+
+func runCrack(cmd *cobra.Command, hashFile string) {
+	opts := profileOptions(cmd.Flags())
+	opts.Workers = workers
+
+	hashes, err := crack.LoadFile(hashFile, hashType)
+	if err != nil {
+		fmt.Printf("Error loading hashes: %v\n", err)
+		os.Exit(1)
+	}
+
+	var potfile *os.File
+	if potfilePath != "" {
+		potfile, err = os.OpenFile(potfilePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+		if err != nil {
+			fmt.Printf("Error opening potfile: %v\n", err)
+			os.Exit(1)
+		}
+		defer potfile.Close()
+	}
+
+	fmt.Printf("Cracking %d hashes...\n", hashes.Len())
+	cracker := crack.NewCracker(hashes, workers, func(hit crack.Hit) {
+		fmt.Println(hit.Potfile())
+		if potfile != nil {
+			fmt.Fprintln(potfile, hit.Potfile())
+		}
+	})
+
+	err = generator.Each(context.Background(), opts, cracker.Try)
+	cracked := cracker.Close()
+	if err != nil && !errors.Is(err, crack.ErrAllCracked) {
+		fmt.Printf("Error generating wordlist: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Cracked %d of %d hashes\n", cracked, hashes.Len())
+}
+
+func init() {
+	rootCmd.AddCommand(crackCmd)
+
+	addProfileFlags(crackCmd.Flags())
+	crackCmd.Flags().StringVar(&hashType, "hash-type", crack.Auto, "Hash type: auto, md5, sha1, sha256, sha512, ntlm or bcrypt")
+	crackCmd.Flags().StringVar(&potfilePath, "potfile", "", "Append cracked hashes to this potfile")
+	crackCmd.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+}
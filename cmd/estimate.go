@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// estimateCmd represents the estimate command
+var estimateCmd = &cobra.Command{
+	Use:   "estimate",
+	Short: "Estimate the size of the wordlist of a profile",
+	Long: `Estimate runs the generation for a profile without writing anything and
+prints the number of passwords and the size of the wordlist as plain text.
+
+Duplicates are removed with a bloom filter, so the count can be slightly low.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runEstimate(cmd)
+	},
+}
+
+func runEstimate(cmd *cobra.Command) {
+	opts := profileOptions(cmd.Flags())
+	opts.Workers = workers
+
+	size, err := generator.Estimate(context.Background(), opts)
+	if err != nil {
+		fmt.Printf("Error estimating wordlist: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Passwords: %d\n", size.Words)
+	fmt.Printf("Size:      %s\n", generator.FormatBytes(size.Bytes))
+}
+
+func init() {
+	rootCmd.AddCommand(estimateCmd)
+
+	addProfileFlags(estimateCmd.Flags())
+	estimateCmd.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+}
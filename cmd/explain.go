@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain <candidate>",
+	Short: "Explain how a password would be generated from a profile",
+	Long: `Explain searches everything the given profile generates for the candidate
+password and prints how it is derived: the profile fields it is built from
+and the transforms applied to them, and its position in the wordlist.
+
+If the profile does not generate the candidate, the closest generated
+password by edit distance is shown instead.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runExplain(cmd, args[0])
+	},
+}
+
+func runExplain(cmd *cobra.Command, password string) {
+	opts := profileOptions(cmd.Flags())
+	opts.Workers = workers
+
+	e, err := generator.Explain(opts, password)
+	if err != nil {
+		fmt.Printf("Error explaining password: %v\n", err)
+		os.Exit(1)
+	}
+
+	if e.Found {
+		fmt.Printf("%q can be generated from this profile:\n", e.Password)
+	} else {
+		fmt.Printf("%q is not generated from this profile.\n", e.Password)
+		fmt.Printf("Closest candidate: %q (edit distance %d)\n", e.Candidate, e.Distance)
+	}
+	fmt.Printf("  derivation: %s\n", e.Derivation)
+	switch {
+	case !e.InLengthRange:
+		fmt.Println("  rank:       not in the wordlist, filtered out by the min/max password length")
+	case !e.StrongEnough:
+		fmt.Println("  rank:       not in the wordlist, filtered out by the minimum strength")
+	default:
+		fmt.Printf("  rank:       %d\n", e.Rank)
+	}
+
+	if !e.Found {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+
+	addProfileFlags(explainCmd.Flags())
+	explainCmd.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+}
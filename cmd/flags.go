@@ -0,0 +1,220 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
+	"github.com/spf13/pflag"
+)
+
+var (
+	// Profile flags, shared by every command that generates candidates
+	profilePath    string
+	firstName      string
+	lastName       string
+	birthday       string
+	relatedWords   string
+	sourceList     []string
+	minLength      int
+	maxLength      int
+	enableLeet     bool
+	enableCap      bool
+	transformList  []string
+	transformSpecs []string
+	separatorList  []string
+	combineDepth   int
+	templates      bool
+	templateFile   string
+	markovModel    string
+	markovSuffixes int
+	markovRank     bool
+	minStrength    int
+
+	workers int
+)
+
+func addProfileFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&profilePath, "profile", "p", "", "Profile file created with the profile init command, overridden by the other flags given")
+	addInputFlags(flags)
+	addVariationFlags(flags)
+}
+
+// addInputFlags adds the flags with the personal information of a profile.
+func addInputFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&firstName, "firstname", "f", "", "First name (and middle name if needed)")
+	flags.StringVarP(&lastName, "lastname", "l", "", "Last name")
+	flags.StringVarP(&birthday, "birthday", "b", "", "Birthday in format DD/MM/YYYY (or similar, use / to separate)")
+	flags.StringVarP(&relatedWords, "words", "w", "", "Related words separated by commas")
+	flags.StringArrayVar(&sourceList, "source", nil, "Extra source of base words as name[:arg,...], e.g. file:pets.txt (repeatable, see the sources list command)")
+}
+
+// addVariationFlags adds the flags that decide what is generated from the
+// profile inputs.
+func addVariationFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&minLength, "min", profile.DefaultMinLength, "Minimum password length")
+	flags.IntVar(&maxLength, "max", profile.DefaultMaxLength, "Maximum password length")
+
+	// Options flags
+	flags.BoolVar(&enableLeet, "leet", false, "Enable leet speak variations (1337)")
+	flags.BoolVar(&enableCap, "caps", false, "Enable capitalization variations")
+	flags.StringSliceVar(&transformList, "transforms", nil, "Transforms to apply in order, separated by commas (see the transforms list command)")
+	flags.StringArrayVar(&transformSpecs, "transform", nil, "Transform to apply after --transforms, with arguments as name[:arg,...], e.g. suffix:1,123 (repeatable)")
+	flags.StringSliceVar(&separatorList, "separators", nil, "Separators to also join combined words with, separated by commas, e.g. _,.")
+	flags.IntVar(&combineDepth, "combine-depth", profile.DefaultCombineDepth, "Most words combined into one password")
+
+	// Template flags
+	flags.BoolVar(&templates, "templates", false, "Enable the built-in password structure templates")
+	flags.StringVar(&templateFile, "template-file", "", "File with password structure templates, one per line")
+
+	// Markov model flags
+	flags.StringVar(&markovModel, "markov", "", "Markov model file created with the train command")
+	flags.IntVar(&markovSuffixes, "markov-suffixes", 5, "Number of likely suffixes to append to each base word")
+	flags.BoolVar(&markovRank, "markov-rank", false, "Sort the wordlist by Markov model likelihood")
+
+	// Strength flags
+	flags.IntVar(&minStrength, "min-strength", 0, "Only keep passwords with at least this estimated strength, from 0 (too guessable) to 4 (very unguessable)")
+}
+
+// flagProfile returns the profile given by the profile flags: the profile
+// file if one is given, with the flags set on the command line applied over
+// it. It exits if the profile is invalid.
+func flagProfile(flags *pflag.FlagSet) profile.Profile {
+	p := profile.Default()
+	if profilePath != "" {
+		var err error
+		p, err = profile.LoadFile(profilePath)
+		if err != nil {
+			fmt.Printf("Error loading profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	set := func(name string) bool {
+		return profilePath == "" || flags.Changed(name)
+	}
+	if set("firstname") {
+		p.FirstName = firstName
+	}
+	if set("lastname") {
+		p.LastName = lastName
+	}
+	if set("birthday") {
+		p.Birthday = birthday
+	}
+	if set("words") {
+		p.Words = profile.SplitWords(relatedWords)
+	}
+	if set("source") {
+		p.Sources = append([]string{}, sourceList...)
+	}
+	applyVariationFlags(&p, set)
+
+	if err := p.Validate(); err != nil {
+		exitInvalid(err)
+	}
+	return p
+}
+
+// exitInvalid reports invalid input and exits with status 2, which tells
+// it apart from failures while running (status 1).
+func exitInvalid(err error) {
+	fmt.Printf("Error: %v\n", err)
+	fmt.Println("Use --help for more information")
+	os.Exit(2)
+}
+
+// profileOptions builds the generator options for the profile given by the
+// profile flags, exiting if the profile is invalid.
+func profileOptions(flags *pflag.FlagSet) generator.Options {
+	return flagProfile(flags).Options()
+}
+
+// applyVariationFlags copies the variation flags for which set returns true
+// onto p.
+func applyVariationFlags(p *profile.Profile, set func(name string) bool) {
+	if set("min") {
+		p.MinLength = minLength
+	}
+	if set("max") {
+		p.MaxLength = maxLength
+	}
+	if set("leet") {
+		p.Leet = enableLeet
+	}
+	if set("caps") {
+		p.Caps = enableCap
+	}
+	if set("transforms") || set("transform") {
+		p.Transforms = append(append([]string{}, transformList...), transformSpecs...)
+	}
+	if set("separators") {
+		p.Separators = append([]string{}, separatorList...)
+	}
+	if set("combine-depth") {
+		p.CombineDepth = combineDepth
+	}
+	if set("templates") {
+		p.Templates = templates
+	}
+	if set("template-file") {
+		p.TemplateFile = templateFile
+	}
+	if set("markov") {
+		p.Markov = markovModel
+	}
+	if set("markov-suffixes") {
+		p.MarkovSuffixes = markovSuffixes
+	}
+	if set("markov-rank") {
+		p.MarkovRank = markovRank
+	}
+	if set("min-strength") {
+		p.MinStrength = minStrength
+	}
+}
+
+// rawProfile builds a profile from the raw input strings, with the
+// variations from the variation flags.
+func rawProfile(firstName, lastName, birthday, relatedWords string) profile.Profile {
+	p := profile.Default()
+	p.FirstName = firstName
+	p.LastName = lastName
+	p.Birthday = birthday
+	p.Words = profile.SplitWords(relatedWords)
+	p.Sources = append([]string{}, sourceList...)
+	applyVariationFlags(&p, func(string) bool { return true })
+	return p
+}
+
+// buildOptions builds the generator options for a profile given as the raw
+// input strings, with the variations from the variation flags.
+func buildOptions(firstName, lastName, birthday, relatedWords string) (generator.Options, error) {
+	p := rawProfile(firstName, lastName, birthday, relatedWords)
+	if err := p.Validate(); err != nil {
+		return generator.Options{}, err
+	}
+	return p.Options(), nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFilePath string
+	dedup          string
+	bloomFPRate    float64
+	tempDir        string
+	resume         bool
+	stateFile      string
+	shard          string
+	compress       string
+	splitLines     int
+	splitSize      int
+	format         string
+)
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a wordlist from a profile",
+	Long: `Generate writes the wordlist for a profile, given with flags or as a profile
+file with --profile, to a file.
+
+The output can be compressed, split into parts, sharded across machines and
+resumed after an interruption.`,
+	Example: `  go-wordlistgen generate -f "John" -l "Doe" -b "01/01/1990" -w "hobby,pet,city" --leet --caps
+  go-wordlistgen generate --profile john.json -o john.txt.gz`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runGenerate(cmd)
+	},
+}
+
+func runGenerate(cmd *cobra.Command) {
+	opts := profileOptions(cmd.Flags())
+	if cmd.Flags().Changed("output") {
+		opts.OutputFilePath = outputFilePath
+	}
+	opts.Workers = workers
+	opts.Dedup = dedup
+	opts.BloomFalsePositiveRate = bloomFPRate
+	opts.TempDir = tempDir
+	opts.Resume = resume
+	opts.StateFilePath = stateFile
+	opts.Compress = compress
+	opts.SplitLines = splitLines
+	opts.SplitMegabytes = splitSize
+	opts.Format = format
+
+	shardIndex, shardCount, err := parseShard(shard)
+	if err != nil {
+		exitInvalid(err)
+	}
+	opts.ShardIndex = shardIndex
+	opts.ShardCount = shardCount
+	if err := opts.Validate(); err != nil {
+		exitInvalid(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Println("Generating wordlist...")
+	err = generator.Run(ctx, opts, progressBar(os.Stderr))
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr)
+		fmt.Println("Generation interrupted, run again with --resume to continue")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("Error generating wordlist: %v\n", err)
+		os.Exit(1)
+	}
+
+	if splitLines > 0 || splitSize > 0 {
+		fmt.Printf("Wordlist parts successfully generated, listed in: %s\n", generator.ManifestPath(opts))
+		return
+	}
+	fmt.Printf("Wordlist successfully generated at: %s\n", generator.OutputPath(opts))
+}
+
+// parseShard parses a shard given as i/n, where i is between 1 and n.
+func parseShard(s string) (int, int, error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	index, count, ok := strings.Cut(s, "/")
+	i, errIndex := strconv.Atoi(index)
+	n, errCount := strconv.Atoi(count)
+	if !ok || errIndex != nil || errCount != nil || n < 1 || i < 1 || i > n {
+		return 0, 0, fmt.Errorf("shard must be in the form i/n with 1 <= i <= n, got %q", s)
+	}
+	return i, n, nil
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+
+	addProfileFlags(generateCmd.Flags())
+	generateCmd.Flags().StringVarP(&outputFilePath, "output", "o", "", "Output file path (default the output of the profile, or wordlist.txt)")
+	generateCmd.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+
+	generateCmd.Flags().StringVar(&format, "format", "", "Output format: plain, jsonl or csv, the last two with the sources and transforms of every password (default from output file extension)")
+	generateCmd.Flags().StringVar(&compress, "compress", "", "Compress the output: none, gzip, zstd or xz (default from output file extension)")
+	generateCmd.Flags().IntVar(&splitLines, "split-lines", 0, "Split the output into parts of at most this many lines")
+	generateCmd.Flags().IntVar(&splitSize, "split-size", 0, "Split the output into parts of at most this many megabytes (before compression)")
+	generateCmd.Flags().StringVar(&shard, "shard", "", "Only generate shard i of n of the wordlist, as i/n (e.g. 2/4)")
+
+	// Resume flags
+	generateCmd.Flags().BoolVar(&resume, "resume", false, "Resume an interrupted run from its state file")
+	generateCmd.Flags().StringVar(&stateFile, "state-file", "", "Checkpoint state file path (default <output>.state)")
+
+	// Deduplication flags
+	generateCmd.Flags().StringVar(&dedup, "dedup", generator.DedupMap, "Deduplication strategy: map (exact, in memory), sort (exact, temp files, sorted output) or bloom (probabilistic)")
+	generateCmd.Flags().Float64Var(&bloomFPRate, "bloom-fp-rate", 0.001, "False positive rate of the bloom dedup strategy")
+	generateCmd.Flags().StringVar(&tempDir, "temp-dir", "", "Directory for temporary files (default system temp dir)")
+
+	// The root command took the generate flags with --cli before there were
+	// commands.
+	generateCmd.Flags().BoolP("cli", "c", false, "Run in CLI mode instead of TUI mode")
+	generateCmd.Flags().MarkDeprecated("cli", "use the generate command instead")
+}
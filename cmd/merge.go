@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// mergeCmd represents the merge command
+var mergeCmd = &cobra.Command{
+	Use:   "merge <wordlist>...",
+	Short: "Merge wordlists into one without duplicates",
+	Long: `Merge concatenates wordlists, one word per line, keeping the first occurrence
+of every word. Compressed wordlists (.gz, .zst, .xz) are read directly, and
+JSON Lines (.jsonl) and CSV (.csv) wordlists are read by their password field.
+
+The merged wordlist holds only the passwords, in the format of its extension,
+and can be compressed and split like the output of generate.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runMerge(args)
+	},
+}
+
+func runMerge(paths []string) {
+	opts := generator.Options{
+		OutputFilePath:         outputFilePath,
+		Dedup:                  dedup,
+		BloomFalsePositiveRate: bloomFPRate,
+		TempDir:                tempDir,
+		Compress:               compress,
+		SplitLines:             splitLines,
+		SplitMegabytes:         splitSize,
+	}
+
+	if err := generator.Merge(opts, paths); err != nil {
+		fmt.Printf("Error merging wordlists: %v\n", err)
+		os.Exit(1)
+	}
+
+	if splitLines > 0 || splitSize > 0 {
+		fmt.Printf("Merged wordlist parts listed in: %s\n", generator.ManifestPath(opts))
+		return
+	}
+	fmt.Printf("Merged wordlist written to: %s\n", generator.OutputPath(opts))
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringVarP(&outputFilePath, "output", "o", "", "Output file path (default wordlist.txt)")
+	mergeCmd.Flags().StringVar(&compress, "compress", "", "Compress the output: none, gzip, zstd or xz (default from output file extension)")
+	mergeCmd.Flags().IntVar(&splitLines, "split-lines", 0, "Split the output into parts of at most this many lines")
+	mergeCmd.Flags().IntVar(&splitSize, "split-size", 0, "Split the output into parts of at most this many megabytes (before compression)")
+
+	// Deduplication flags
+	mergeCmd.Flags().StringVar(&dedup, "dedup", generator.DedupMap, "Deduplication strategy: map (exact, in memory), sort (exact, temp files, sorted output) or bloom (probabilistic)")
+	mergeCmd.Flags().Float64Var(&bloomFPRate, "bloom-fp-rate", 0.001, "False positive rate of the bloom dedup strategy")
+	mergeCmd.Flags().StringVar(&tempDir, "temp-dir", "", "Directory for temporary files (default system temp dir)")
+}
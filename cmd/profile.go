@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
+	"github.com/spf13/cobra"
+)
+
+var profileForce bool
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Create and check profile files",
+	Long: `A profile file stores the personal information of a target and the
+variations to generate from it as JSON, so a run can be repeated with
+--profile instead of retyping every flag.`,
+}
+
+// profileInitCmd represents the profile init command
+var profileInitCmd = &cobra.Command{
+	Use:   "init [file]",
+	Short: "Create a profile file",
+	Long: `Init writes a new profile file (default profile.json) from the profile flags
+given, or with empty fields to fill in.`,
+	Example: `  go-wordlistgen profile init john.json -f "John" -l "Doe" -b "01/01/1990" --leet`,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := "profile.json"
+		if len(args) > 0 {
+			path = args[0]
+		}
+		runProfileInit(path)
+	},
+}
+
+// profileValidateCmd represents the profile validate command
+var profileValidateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Check that a profile file can be generated from",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runProfileValidate(args[0])
+	},
+}
+
+func runProfileInit(path string) {
+	if _, err := os.Stat(path); err == nil && !profileForce {
+		fmt.Printf("Error: %s already exists, use --force to overwrite it\n", path)
+		os.Exit(1)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := rawProfile(firstName, lastName, birthday, relatedWords)
+	if err := p.SaveFile(path); err != nil {
+		fmt.Printf("Error saving profile: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Profile saved at: %s\n", path)
+}
+
+func runProfileValidate(path string) {
+	p, err := profile.LoadFile(path)
+	if err != nil {
+		fmt.Printf("Error loading profile: %v\n", err)
+		os.Exit(1)
+	}
+	if err := p.Validate(); err != nil {
+		fmt.Printf("Error: %s: %v\n", path, err)
+		os.Exit(2)
+	}
+	if _, err := generator.Templates(p.Options()); err != nil {
+		fmt.Printf("Error: %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	if p.Markov != "" {
+		if _, err := os.Stat(p.Markov); err != nil {
+			fmt.Printf("Error: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("%s is valid\n", path)
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileInitCmd, profileValidateCmd)
+
+	addInputFlags(profileInitCmd.Flags())
+	addVariationFlags(profileInitCmd.Flags())
+	profileInitCmd.Flags().BoolVar(&profileForce, "force", false, "Overwrite an existing profile file")
+}
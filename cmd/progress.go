@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+)
+
+const progressBarWidth = 30
+
+// progressBar returns a progress callback that draws a progress bar on a
+// single line of w, or nil when w is not a terminal.
+func progressBar(w *os.File) func(generator.Progress) {
+	if info, err := w.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return func(p generator.Progress) {
+		drawProgress(w, p)
+		if p.Done {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func drawProgress(w io.Writer, p generator.Progress) {
+	filled := int(p.Fraction() * progressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+
+	eta := "ETA --"
+	if p.Done {
+		eta = "in " + p.Elapsed.Round(time.Second).String()
+	} else if p.JobsDone > 0 {
+		eta = "ETA " + p.ETA().Round(time.Second).String()
+	}
+	// \033[K clears what is left of a longer previous line.
+	fmt.Fprintf(w, "\r%s %3.0f%%  %d written  %d dropped  %s/s  %s\033[K",
+		bar, p.Fraction()*100, p.Written, p.Dropped, formatCount(p.Rate()), eta)
+}
+
+// formatCount formats a count with a decimal unit, e.g. 12.3k.
+func formatCount(n float64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fG", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fk", n/1e3)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
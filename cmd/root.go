@@ -22,29 +22,13 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"strings"
 
-	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
 	"github.com/efeaslansoyler/go-wordlistgen/internal/tui"
 	"github.com/spf13/cobra"
 )
 
-var (
-	// CLI mode flags
-	cliMode        bool
-	firstName      string
-	lastName       string
-	birthday       string
-	relatedWords   string
-	minLength      string
-	maxLength      string
-	outputFilePath string
-	enableLeet     bool
-	enableCap      bool
-)
-
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "go-wordlistgen",
@@ -54,89 +38,37 @@ It generates potential passwords by combining personal information such as names
 birth dates, and related words with common variations like capitalization and 
 leet (1337) speak substitutions.
 
-You can use either the interactive TUI mode (default) or the CLI mode with flags.`,
+Run without a command to fill in a profile in the interactive TUI, or use one of
+the commands below, starting with generate.`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		if cliMode {
-			runCLIMode()
-		} else {
-			tui.Start()
-		}
+		tui.Start()
 	},
 }
 
-func runCLIMode() {
-	if firstName == "" || lastName == "" {
-		fmt.Println("Error: both first name and last name are required")
-		fmt.Println("Use --help for more information")
-		os.Exit(1)
-	}
-
-	firstNames := strings.Fields(firstName)
-	lastNames := strings.Fields(lastName)
-	birthdaySlice := []string{}
-
-	if birthday != "" {
-		birthdaySlice = strings.Split(birthday, "/")
-	}
-
-	var relatedWordsSlice []string
-	if relatedWords != "" {
-		for _, word := range strings.Split(relatedWords, ",") {
-			if trimmed := strings.TrimSpace(word); trimmed != "" {
-				relatedWordsSlice = append(relatedWordsSlice, trimmed)
-			}
-		}
-	}
-
-	opts := generator.Options{
-		InputFirstName:    firstNames,
-		InputLastName:     lastNames,
-		InputBirthday:     birthdaySlice,
-		InputRelatedWords: relatedWordsSlice,
-		InputMinLength:    minLength,
-		InputMaxLength:    maxLength,
-		EnableLeet:        enableLeet,
-		EnableCapitalize:  enableCap,
-		OutputFilePath:    outputFilePath,
-	}
-
-	fmt.Println("Generating wordlist...")
-	err := generator.Run(opts)
-	if err != nil {
-		fmt.Printf("Error generating wordlist: %v\n", err)
-		os.Exit(1)
-	}
-
-	outputPath := outputFilePath
-	if outputPath == "" {
-		outputPath = "wordlist.txt"
-	}
-	fmt.Printf("Wordlist successfully generated at: %s\n", outputPath)
-}
-
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	rootCmd.SetArgs(legacyArgs(os.Args[1:]))
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		// Commands exit on their own once running, so only invalid
+		// commands, arguments and flags end up here.
+		os.Exit(2)
 	}
 }
 
-func init() {
-	// Define flags for CLI mode
-	rootCmd.Flags().BoolVarP(&cliMode, "cli", "c", false, "Run in CLI mode instead of TUI mode")
-
-	// Input flags
-	rootCmd.Flags().StringVarP(&firstName, "firstname", "f", "", "First name (and middle name if needed)")
-	rootCmd.Flags().StringVarP(&lastName, "lastname", "l", "", "Last name")
-	rootCmd.Flags().StringVarP(&birthday, "birthday", "b", "", "Birthday in format DD/MM/YYYY (or similar, use / to separate)")
-	rootCmd.Flags().StringVarP(&relatedWords, "words", "w", "", "Related words separated by commas")
-	rootCmd.Flags().StringVar(&minLength, "min", "", "Minimum password length (default 6)")
-	rootCmd.Flags().StringVar(&maxLength, "max", "", "Maximum password length (default 12)")
-	rootCmd.Flags().StringVarP(&outputFilePath, "output", "o", "", "Output file path (default wordlist.txt)")
-
-	// Options flags
-	rootCmd.Flags().BoolVar(&enableLeet, "leet", false, "Enable leet speak variations (1337)")
-	rootCmd.Flags().BoolVar(&enableCap, "caps", false, "Enable capitalization variations")
+// legacyArgs passes the invocations of the --cli (-c) flag, which ran the
+// generator before there were commands, on to the generate command, where
+// the flag is deprecated.
+func legacyArgs(args []string) []string {
+	if len(args) == 0 || !strings.HasPrefix(args[0], "-") {
+		return args
+	}
+	for _, arg := range args {
+		if arg == "--cli" || arg == "-c" || arg == "--cli=true" {
+			return append([]string{generateCmd.Name()}, args...)
+		}
+	}
+	return args
 }
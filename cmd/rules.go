@@ -0,0 +1,73 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the password structure templates",
+	Long: `Rules lists the password structure templates, the built-in ones by default,
+or those of --template-file after checking that they are valid.
+
+A template is literal text with placeholders filled from the profile:
+  {first} {last} {name} {word}   first names, last names, both, related words
+  {dd} {mm} {yyyy} {yy}          birthday day, month, year and two digit year
+  {sym} {num} {digit}            common symbols, numbers and single digits
+
+The case of a placeholder sets the case of its value ({first}, {First},
+{FIRST}), or a modifier does: {first:raw}, {first:lower}, {first:upper},
+{first:cap}, {first:leet} or {first:initial}.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runRules()
+	},
+}
+
+func runRules() {
+	opts := generator.Options{
+		EnableTemplates:  templates || templateFile == "",
+		TemplateFilePath: templateFile,
+	}
+	rules, err := generator.Templates(opts)
+	if err != nil {
+		fmt.Printf("Error loading templates: %v\n", err)
+		os.Exit(1)
+	}
+	for _, rule := range rules {
+		fmt.Println(rule)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+
+	rulesCmd.Flags().BoolVar(&templates, "templates", false, "List the built-in templates along with those of --template-file")
+	rulesCmd.Flags().StringVar(&templateFile, "template-file", "", "File with password structure templates, one per line")
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// sourcesCmd represents the sources command
+var sourcesCmd = &cobra.Command{
+	Use:   "sources",
+	Short: "Inspect the sources of base words",
+	Long: `Sources produce the base words that candidates are combined from. The
+names, birthday and related words of a profile are sources; more are added
+with --source name[:arg,...] (or the sources field of a profile file), e.g.
+--source file:pets.txt --source numbers:1990-2025.`,
+}
+
+// sourcesListCmd represents the sources list command
+var sourcesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available sources",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range generator.Sources() {
+			fmt.Fprintf(w, "%s\t%s\n", s.Name(), s.Description())
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sourcesCmd)
+	sourcesCmd.AddCommand(sourcesListCmd)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var statsStrength bool
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats <wordlist>",
+	Short: "Show statistics about a wordlist",
+	Long: `Stats reads a wordlist, one word per line and optionally compressed, and prints
+the number of words, duplicates, a histogram of word lengths and the classes
+of characters the words are made of. JSON Lines (.jsonl) and CSV (.csv)
+wordlists are read by their password field.
+
+With --strength the words are also counted by estimated strength.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runStats(args[0])
+	},
+}
+
+func runStats(path string) {
+	r, err := generator.OpenWordlist(path)
+	if err != nil {
+		fmt.Printf("Error opening wordlist: %v\n", err)
+		os.Exit(1)
+	}
+	defer r.Close()
+
+	s, err := stats.Collect(r, generator.WordlistFormat(path), statsStrength)
+	if err != nil {
+		fmt.Printf("Error reading wordlist: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Words:      %d\n", s.Words)
+	fmt.Printf("Unique:     %d\n", s.Unique)
+	fmt.Printf("Size:       %s\n", generator.FormatBytes(s.Bytes))
+	if s.Words == 0 {
+		return
+	}
+	fmt.Printf("Length:     %d to %d, %.1f on average\n", s.MinLength, s.MaxLength, s.AverageLength())
+
+	fmt.Println("\nLengths:")
+	lengths := make([]int, 0, len(s.Lengths))
+	for length := range s.Lengths {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+	for _, length := range lengths {
+		printBar(fmt.Sprintf("%3d", length), s.Lengths[length], s.Words)
+	}
+
+	fmt.Println("\nCharacters:")
+	for _, c := range s.SortedCharsets() {
+		printBar(fmt.Sprintf("%-25s", c.Value), c.Words, s.Words)
+	}
+
+	if s.Strength != nil {
+		fmt.Println("\nStrength:")
+		for score, words := range s.Strength {
+			printBar(fmt.Sprintf("%3d", score), words, s.Words)
+		}
+	}
+}
+
+// printBar prints a labeled count with its share of total as a bar.
+func printBar(label string, count, total int64) {
+	const width = 40
+	share := float64(count) / float64(total)
+	bar := strings.Repeat("█", int(share*width+0.5))
+	fmt.Printf("  %s %10d %5.1f%% %s\n", label, count, share*100, bar)
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsStrength, "strength", false, "Also count the words by estimated strength")
+}
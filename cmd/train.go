@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/markov"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trainOrder      int
+	trainOutputPath string
+)
+
+// trainCmd represents the train command
+var trainCmd = &cobra.Command{
+	Use:   "train <corpus>",
+	Short: "Train a Markov model from a local password corpus",
+	Long: `Train builds a character-level Markov model from a local password corpus
+(one password per line) and saves it as a compact model file.
+
+The model can then be passed to the generator with --markov to extend base
+words with likely suffixes and to rank candidates by likelihood, fully offline.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTrain(args[0])
+	},
+}
+
+func runTrain(corpusPath string) {
+	corpus, err := os.Open(corpusPath)
+	if err != nil {
+		fmt.Printf("Error opening corpus: %v\n", err)
+		os.Exit(1)
+	}
+	defer corpus.Close()
+
+	fmt.Println("Training model...")
+	model, err := markov.Train(corpus, trainOrder)
+	if err != nil {
+		fmt.Printf("Error training model: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := model.SaveFile(trainOutputPath); err != nil {
+		fmt.Printf("Error saving model: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Model trained on %d passwords saved at: %s\n", model.Words, trainOutputPath)
+}
+
+func init() {
+	rootCmd.AddCommand(trainCmd)
+
+	trainCmd.Flags().IntVar(&trainOrder, "order", markov.DefaultOrder, "Number of previous characters the model looks at")
+	trainCmd.Flags().StringVarP(&trainOutputPath, "output", "o", "markov.model", "Output model file path")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2025 Efe Aslan Söyler efeaslan1703@gmail.com
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// transformsCmd represents the transforms command
+var transformsCmd = &cobra.Command{
+	Use:   "transforms",
+	Short: "Inspect the transforms that derive password variants",
+	Long: `Transforms derive variants of every candidate, such as its leet speak
+spelling. They are chosen and ordered with --transforms (or the transforms
+field of a profile file); each one applies to every candidate so far.`,
+}
+
+// transformsListCmd represents the transforms list command
+var transformsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available transforms",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, t := range generator.Transforms() {
+			fmt.Fprintf(w, "%s\t%s\n", t.Name(), t.Description())
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transformsCmd)
+	transformsCmd.AddCommand(transformsListCmd)
+}
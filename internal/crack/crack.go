@@ -0,0 +1,282 @@
+package crack
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/md4"
+)
+
+const (
+	Auto   = "auto"
+	MD5    = "md5"
+	SHA1   = "sha1"
+	SHA256 = "sha256"
+	SHA512 = "sha512"
+	NTLM   = "ntlm"
+	Bcrypt = "bcrypt"
+)
+
+// ErrAllCracked is returned by Try once every hash was cracked, so the
+// caller can stop generating candidates.
+var ErrAllCracked = errors.New("all hashes cracked")
+
+// fastAlgorithms are the unsalted hashes, looked up by digest.
+var fastAlgorithms = map[string]func(password string) string{
+	MD5:    hexDigest(md5.New),
+	SHA1:   hexDigest(sha1.New),
+	SHA256: hexDigest(sha256.New),
+	SHA512: hexDigest(sha512.New),
+	NTLM:   ntlm,
+}
+
+func hexDigest(newHash func() hash.Hash) func(string) string {
+	return func(password string) string {
+		h := newHash()
+		h.Write([]byte(password))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+}
+
+// ntlm is MD4 over the UTF-16LE encoding of the password.
+func ntlm(password string) string {
+	h := md4.New()
+	for _, u := range utf16.Encode([]rune(password)) {
+		h.Write([]byte{byte(u), byte(u >> 8)})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// detect returns the algorithms a hash may have been made with, judging by
+// its shape. A 32 character hex hash can be either MD5 or NTLM.
+func detect(h string) []string {
+	if strings.HasPrefix(h, "$2a$") || strings.HasPrefix(h, "$2b$") || strings.HasPrefix(h, "$2y$") {
+		return []string{Bcrypt}
+	}
+	if _, err := hex.DecodeString(h); err != nil {
+		return nil
+	}
+	switch len(h) {
+	case 32:
+		return []string{MD5, NTLM}
+	case 40:
+		return []string{SHA1}
+	case 64:
+		return []string{SHA256}
+	case 128:
+		return []string{SHA512}
+	}
+	return nil
+}
+
+// HashList is a set of target hashes grouped by algorithm.
+type HashList struct {
+	fast   map[string]map[string]string
+	bcrypt []string
+	count  int
+}
+
+// Load reads one hash per line from r. Blank lines and lines starting with
+// # are skipped. With hashType Auto the algorithm of every hash is detected
+// from its shape, otherwise every hash must have the shape of hashType.
+func Load(r io.Reader, hashType string) (*HashList, error) {
+	if hashType == "" {
+		hashType = Auto
+	}
+	if _, ok := fastAlgorithms[hashType]; !ok && hashType != Auto && hashType != Bcrypt {
+		return nil, fmt.Errorf("unknown hash type %q (use %s, %s, %s, %s, %s, %s or %s)", hashType, Auto, MD5, SHA1, SHA256, SHA512, NTLM, Bcrypt)
+	}
+
+	list := &HashList{fast: make(map[string]map[string]string)}
+	seen := make(map[string]struct{})
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		h := strings.TrimSpace(scanner.Text())
+		if h == "" || strings.HasPrefix(h, "#") {
+			continue
+		}
+		// Hex digests are the same hash in either case.
+		key := h
+		if _, err := hex.DecodeString(h); err == nil {
+			key = strings.ToLower(h)
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		algorithms := detect(h)
+		if hashType == Auto && len(algorithms) == 0 {
+			return nil, fmt.Errorf("line %d: unrecognized hash %q", line, h)
+		}
+		if hashType != Auto {
+			if !slices.Contains(algorithms, hashType) {
+				return nil, fmt.Errorf("line %d: %q is not a valid %s hash", line, h, hashType)
+			}
+			algorithms = []string{hashType}
+		}
+		for _, algorithm := range algorithms {
+			if algorithm == Bcrypt {
+				list.bcrypt = append(list.bcrypt, h)
+				continue
+			}
+			if list.fast[algorithm] == nil {
+				list.fast[algorithm] = make(map[string]string)
+			}
+			list.fast[algorithm][strings.ToLower(h)] = h
+		}
+		list.count++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if list.count == 0 {
+		return nil, fmt.Errorf("hash list does not contain any hashes")
+	}
+	return list, nil
+}
+
+func LoadFile(path, hashType string) (*HashList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file, hashType)
+}
+
+// Len returns the number of distinct hashes in the list.
+func (l *HashList) Len() int {
+	return l.count
+}
+
+// Hit is a cracked hash.
+type Hit struct {
+	Hash      string
+	Password  string
+	Algorithm string
+}
+
+// Potfile returns the hit as a hashcat style potfile line, hex encoding
+// passwords that contain characters that are not printable.
+func (h Hit) Potfile() string {
+	password := h.Password
+	for _, char := range password {
+		if !unicode.IsPrint(char) {
+			password = "$HEX[" + hex.EncodeToString([]byte(h.Password)) + "]"
+			break
+		}
+	}
+	return h.Hash + ":" + password
+}
+
+// Cracker checks candidates against a hash list on a pool of workers.
+type Cracker struct {
+	list       *HashList
+	onHit      func(Hit)
+	candidates chan string
+	wg         sync.WaitGroup
+
+	mu      sync.Mutex
+	cracked map[string]struct{}
+	done    chan struct{}
+}
+
+// NewCracker starts workers that check candidates passed to Try against
+// list and call onHit, one at a time, for every hash cracked.
+func NewCracker(list *HashList, workers int, onHit func(Hit)) *Cracker {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	c := &Cracker{
+		list:       list,
+		onHit:      onHit,
+		candidates: make(chan string, workers*16),
+		cracked:    make(map[string]struct{}),
+		done:       make(chan struct{}),
+	}
+	for w := 0; w < workers; w++ {
+		c.wg.Add(1)
+		go c.work()
+	}
+	return c
+}
+
+// Try queues a candidate. It returns ErrAllCracked once every hash is
+// cracked.
+func (c *Cracker) Try(password string) error {
+	select {
+	case <-c.done:
+		return ErrAllCracked
+	case c.candidates <- password:
+		return nil
+	}
+}
+
+// Close waits for the queued candidates to be checked and returns the
+// number of hashes cracked.
+func (c *Cracker) Close() int {
+	close(c.candidates)
+	c.wg.Wait()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cracked)
+}
+
+func (c *Cracker) work() {
+	defer c.wg.Done()
+	for password := range c.candidates {
+		for algorithm, digests := range c.list.fast {
+			if h, ok := digests[fastAlgorithms[algorithm](password)]; ok {
+				c.hit(Hit{Hash: h, Password: password, Algorithm: algorithm})
+			}
+		}
+		for _, h := range c.list.bcrypt {
+			if c.isCracked(h) {
+				continue
+			}
+			if bcrypt.CompareHashAndPassword([]byte(h), []byte(password)) == nil {
+				c.hit(Hit{Hash: h, Password: password, Algorithm: Bcrypt})
+			}
+		}
+	}
+}
+
+func (c *Cracker) isCracked(h string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.cracked[h]
+	return ok
+}
+
+func (c *Cracker) hit(h Hit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.cracked[h.Hash]; ok {
+		return
+	}
+	c.cracked[h.Hash] = struct{}{}
+	c.onHit(h)
+	if len(c.cracked) == c.list.count {
+		close(c.done)
+	}
+}
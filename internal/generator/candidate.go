@@ -0,0 +1,56 @@
+package generator
+
+import "github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+
+// candidate is a generated password together with where it came from: the
+// input tokens it was built from, the transforms applied to them in order
+// and, when a Markov model is loaded, its likelihood score. Strength is set
+// when the output includes it or candidates are filtered by it.
+type candidate struct {
+	Word       string
+	Sources    []string
+	Transforms []string
+	Score      *float64
+	Strength   *strength.Result
+}
+
+// Candidate is a generated password with the input tokens it was built
+// from, the transforms applied to them in order and its Markov score, if a
+// model is loaded.
+type Candidate struct {
+	Password   string
+	Sources    []string
+	Transforms []string
+	Score      *float64
+}
+
+func (c candidate) export() Candidate {
+	return Candidate{Password: c.Word, Sources: c.Sources, Transforms: c.Transforms, Score: c.Score}
+}
+
+func newCandidate(word string, sources []string, transforms ...string) candidate {
+	return candidate{Word: word, Sources: sources, Transforms: transforms}
+}
+
+// derive returns a candidate for word, made by applying transform to c.
+func (c candidate) derive(word, transform string) candidate {
+	transforms := make([]string, len(c.Transforms), len(c.Transforms)+1)
+	copy(transforms, c.Transforms)
+	return candidate{
+		Word:       word,
+		Sources:    c.Sources,
+		Transforms: append(transforms, transform),
+	}
+}
+
+func removeDuplicateCandidates(candidates []candidate) []candidate {
+	seen := make(map[string]struct{})
+	result := []candidate{}
+	for _, c := range candidates {
+		if _, ok := seen[c.Word]; !ok {
+			seen[c.Word] = struct{}{}
+			result = append(result, c)
+		}
+	}
+	return result
+}
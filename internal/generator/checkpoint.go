@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// checkpointInterval is the number of words written between checkpoints,
+// a variable so tests can interrupt runs after a few.
+var checkpointInterval int64 = 100000
+
+// runState records how far a run got so it can be resumed. Because the
+// enumeration is deterministic, the number of words already written is
+// enough to know where to continue.
+type runState struct {
+	Fingerprint string `json:"fingerprint"`
+	Written     int64  `json:"written"`
+	Offset      int64  `json:"offset"`
+}
+
+func statePath(opts Options) string {
+	if opts.StateFilePath != "" {
+		return opts.StateFilePath
+	}
+	return OutputPath(opts) + ".state"
+}
+
+// fingerprint identifies the options and the contents of the input files
+// that decide which words are generated and in which order, so a state file
+// is never used for a different run.
+func fingerprint(opts Options) (string, error) {
+	opts.Workers = 0
+	opts.Resume = false
+	opts.StateFilePath = ""
+	opts.TempDir = ""
+	data, _ := json.Marshal(opts)
+	h := sha256.New()
+	h.Write(data)
+	for _, path := range inputFiles(opts) {
+		if err := hashFile(h, path); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// inputFiles returns the paths of the files opts reads words, templates or
+// a model from.
+func inputFiles(opts Options) []string {
+	var paths []string
+	if opts.MarkovModelPath != "" {
+		paths = append(paths, opts.MarkovModelPath)
+	}
+	if opts.TemplateFilePath != "" {
+		paths = append(paths, opts.TemplateFilePath)
+	}
+	for _, spec := range opts.Sources {
+		if spec.Name == (fileSource{}).Name() {
+			paths = append(paths, spec.Args...)
+		}
+	}
+	return paths
+}
+
+func hashFile(h hash.Hash, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fmt.Fprintf(h, "\x00%s\x00", path)
+	_, err = io.Copy(h, f)
+	return err
+}
+
+func loadState(path string) (runState, error) {
+	var state runState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, fmt.Errorf("nothing to resume: state file %s not found", path)
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func saveState(path string, state runState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// checkpointSink skips the words a previous run already wrote and saves the
+// run state every checkpointInterval words.
+type checkpointSink struct {
+	sink
+	path  string
+	state runState
+	skip  int64
+}
+
+// openCheckpointSink opens the output for a new run, or for a resumed one
+// continuing from the saved state.
+func openCheckpointSink(opts Options, compress string, f format) (*checkpointSink, error) {
+	path := statePath(opts)
+	sum, err := fingerprint(opts)
+	if err != nil {
+		return nil, err
+	}
+	state := runState{Fingerprint: sum}
+
+	if opts.Resume {
+		saved, err := loadState(path)
+		if err != nil {
+			return nil, err
+		}
+		if saved.Fingerprint != state.Fingerprint {
+			return nil, fmt.Errorf("cannot resume: state file %s belongs to a run with different options or input files", path)
+		}
+		state = saved
+	}
+
+	out, err := openSink(opts, compress, f, state.Offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveState(path, state); err != nil {
+		out.Close()
+		return nil, err
+	}
+	return &checkpointSink{sink: out, path: path, state: state, skip: state.Written}, nil
+}
+
+func (s *checkpointSink) Write(c candidate) error {
+	if s.skip > 0 {
+		s.skip--
+		s.sink.Skip(c)
+		return nil
+	}
+	if err := s.sink.Write(c); err != nil {
+		return err
+	}
+	s.state.Written++
+	if s.state.Written%checkpointInterval == 0 {
+		return s.checkpoint()
+	}
+	return nil
+}
+
+func (s *checkpointSink) checkpoint() error {
+	offset, err := s.sink.Checkpoint()
+	if err != nil {
+		return err
+	}
+	s.state.Offset = offset
+	return saveState(s.path, s.state)
+}
+
+// finish closes the output of a completed run and removes its state file.
+func (s *checkpointSink) finish() error {
+	if err := closeCompleted(s.sink); err != nil {
+		return err
+	}
+	return os.Remove(s.path)
+}
@@ -0,0 +1,243 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/markov"
+)
+
+func TestResumeChangedInput(t *testing.T) {
+	tests := []struct {
+		name string
+		// opts returns options reading the input file at path.
+		opts          func(path string) Options
+		before, after []byte
+	}{
+		{
+			name: "file source",
+			opts: func(path string) Options {
+				return Options{Sources: []SourceSpec{{Name: "file", Args: []string{path}}}}
+			},
+			before: []byte("rex\nfido\n"),
+			after:  []byte("rex\nbuddy\n"),
+		},
+		{
+			name:   "template file",
+			opts:   func(path string) Options { return Options{TemplateFilePath: path} },
+			before: []byte("{First}{yyyy}\n"),
+			after:  []byte("{First}{Sym}\n"),
+		},
+		{
+			name:   "markov model",
+			opts:   func(path string) Options { return Options{MarkovModelPath: path, MarkovSuffixes: 2} },
+			before: trainModel(t, "john123\njohn2024\n"),
+			after:  trainModel(t, "doe!\ndoe99\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "input")
+			writeFile(t, input, tt.before)
+			opts := tt.opts(input)
+			opts.InputFirstName = []string{"John"}
+			opts.InputLastName = []string{"Doe"}
+			opts.OutputFilePath = filepath.Join(dir, "out.txt")
+
+			// A run that was stopped before its first checkpoint.
+			sum, err := fingerprint(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := saveState(statePath(opts), runState{Fingerprint: sum}); err != nil {
+				t.Fatal(err)
+			}
+
+			writeFile(t, input, tt.after)
+			opts.Resume = true
+			err = Run(context.Background(), opts, nil)
+			if err == nil || !strings.Contains(err.Error(), "cannot resume") {
+				t.Fatalf("resume with a changed input: got error %v, want a fingerprint mismatch", err)
+			}
+
+			writeFile(t, input, tt.before)
+			if err := Run(context.Background(), opts, nil); err != nil {
+				t.Fatalf("resume with the same input: %v", err)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// trainModel returns a saved Markov model trained on words. Saving is not
+// deterministic, so the same words may give other bytes on every call.
+func trainModel(t *testing.T, words string) []byte {
+	t.Helper()
+	model, err := markov.Train(strings.NewReader(words), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := model.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var errCrash = errors.New("crash")
+
+// crashSink stops a run after a number of words, like a process killed
+// without closing its output.
+type crashSink struct {
+	*checkpointSink
+	left int
+}
+
+func (s *crashSink) Write(c candidate) error {
+	if s.left == 0 {
+		return errCrash
+	}
+	s.left--
+	return s.checkpointSink.Write(c)
+}
+
+// crash runs the generation of opts like Run, until it is stopped after
+// words words.
+func crash(t *testing.T, opts Options, words int) {
+	t.Helper()
+	g, err := prepare(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compress, err := resolveCompression(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := resolveFormat(opts, compress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.rateStrength = g.rateStrength || f.strength
+	out, err := openCheckpointSink(opts, compress, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(context.Background(), opts, g, &crashSink{checkpointSink: out, left: words}); err != errCrash {
+		t.Fatalf("interrupted run: got error %v, want %v", err, errCrash)
+	}
+}
+
+// readOutput returns the lines of the output of opts, of every part in
+// order when it is split.
+func readOutput(t *testing.T, opts Options) []string {
+	t.Helper()
+	paths := []string{OutputPath(opts)}
+	if opts.SplitLines > 0 || opts.SplitMegabytes > 0 {
+		manifest, err := os.ReadFile(ManifestPath(opts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = nil
+		for _, line := range strings.Split(strings.TrimSpace(string(manifest)), "\n") {
+			name, _, _ := strings.Cut(line, "\t")
+			paths = append(paths, filepath.Join(filepath.Dir(OutputPath(opts)), name))
+		}
+	}
+
+	var lines []string
+	for _, path := range paths {
+		r, err := OpenWordlist(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ScanWordlist(r, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return lines
+}
+
+func TestResume(t *testing.T) {
+	interval := checkpointInterval
+	checkpointInterval = 100
+	t.Cleanup(func() { checkpointInterval = interval })
+
+	tests := []struct {
+		name   string
+		output string
+		opts   Options
+		// crash is the number of words written before the run stops.
+		crash int
+	}{
+		{name: "before the first checkpoint", output: "out.txt", crash: 50},
+		{name: "plain", output: "out.txt", crash: 1050},
+		{name: "at a checkpoint", output: "out.txt", crash: 1000},
+		{name: "gzip", output: "out.txt.gz", crash: 1050},
+		{name: "zstd", output: "out.txt.zst", crash: 1050},
+		{name: "xz", output: "out.txt.xz", crash: 1050},
+		{name: "csv", output: "out.csv", crash: 1050},
+		{name: "split", output: "out.txt", opts: Options{SplitLines: 300}, crash: 1050},
+		{name: "split gzip csv", output: "out.csv.gz", opts: Options{SplitLines: 300}, crash: 1050},
+		{name: "sort dedup", output: "out.txt", opts: Options{Dedup: DedupSort}, crash: 1050},
+		{name: "parallel", output: "out.txt", opts: Options{Workers: 4}, crash: 1050},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.InputFirstName = []string{"John"}
+			opts.InputLastName = []string{"Doe"}
+			opts.InputBirthday = []string{"03", "07", "1991"}
+			opts.InputRelatedWords = []string{"rex", "blue"}
+			opts.EnableLeet = true
+			opts.EnableCapitalize = true
+			opts.TempDir = t.TempDir()
+
+			want := opts
+			want.OutputFilePath = filepath.Join(t.TempDir(), tt.output)
+			if err := Run(context.Background(), want, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			opts.OutputFilePath = filepath.Join(t.TempDir(), tt.output)
+			crash(t, opts, tt.crash)
+			if _, err := os.Stat(ManifestPath(opts)); err == nil {
+				t.Error("interrupted run wrote a manifest")
+			}
+			opts.Resume = true
+			if err := Run(context.Background(), opts, nil); err != nil {
+				t.Fatalf("resume: %v", err)
+			}
+			if _, err := os.Stat(statePath(opts)); !os.IsNotExist(err) {
+				t.Errorf("state file left after the run completed: %v", err)
+			}
+
+			got, wantLines := readOutput(t, opts), readOutput(t, want)
+			if len(wantLines) <= tt.crash {
+				t.Fatalf("only %d words, the run must be interrupted before the end", len(wantLines))
+			}
+			if !slices.Equal(got, wantLines) {
+				t.Errorf("resumed output differs: got %d lines, want %d", len(got), len(wantLines))
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package generator
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+)
+
+const (
+	DedupMap   = "map"
+	DedupSort  = "sort"
+	DedupBloom = "bloom"
+
+	defaultBloomFalsePositiveRate = 0.001
+)
+
+// The chunk size of the sort strategy and the first capacity of the bloom
+// strategy are variables so tests can spill chunks and grow filters with
+// few words.
+var (
+	sortChunkWords              = 1 << 20
+	bloomInitialCapacity uint64 = 1 << 20
+)
+
+// deduper drops words that were already seen and passes the others on.
+// Depending on the strategy words are passed on as they arrive or once
+// everything was added, when Flush is called. Close releases any resources
+// and must be called even if Flush is not.
+type deduper interface {
+	Add(c candidate) error
+	Flush() error
+	Close() error
+}
+
+// validateDedup checks the dedup strategy of opts and its settings.
+func validateDedup(opts Options) error {
+	switch opts.Dedup {
+	case "", DedupMap, DedupSort, DedupBloom:
+	default:
+		return fmt.Errorf("unknown dedup strategy %q (use %s, %s or %s)", opts.Dedup, DedupMap, DedupSort, DedupBloom)
+	}
+	if rate := opts.BloomFalsePositiveRate; rate < 0 || rate >= 1 {
+		return fmt.Errorf("bloom false positive rate must be between 0 and 1")
+	}
+	return nil
+}
+
+func newDeduper(opts Options, emit func(candidate) error) (deduper, error) {
+	if err := validateDedup(opts); err != nil {
+		return nil, err
+	}
+	switch opts.Dedup {
+	case DedupSort:
+		return &sortDeduper{tempDir: opts.TempDir, emit: emit}, nil
+	case DedupBloom:
+		rate := opts.BloomFalsePositiveRate
+		if rate == 0 {
+			rate = defaultBloomFalsePositiveRate
+		}
+		return &bloomDeduper{filter: newScalableBloom(rate), emit: emit}, nil
+	}
+	return &mapDeduper{seen: make(map[string]struct{}), emit: emit}, nil
+}
+
+// mapDeduper keeps every word in memory and is exact.
+type mapDeduper struct {
+	seen map[string]struct{}
+	emit func(candidate) error
+}
+
+func (d *mapDeduper) Add(c candidate) error {
+	if _, ok := d.seen[c.Word]; ok {
+		return nil
+	}
+	d.seen[c.Word] = struct{}{}
+	return d.emit(c)
+}
+
+func (d *mapDeduper) Flush() error {
+	return nil
+}
+
+func (d *mapDeduper) Close() error {
+	return nil
+}
+
+// sortDeduper is exact and keeps only one chunk of words in memory. Chunks
+// are sorted and spilled to temporary files which are merged on Flush, so the
+// output comes out sorted.
+type sortDeduper struct {
+	tempDir string
+	dir     string
+	chunk   []candidate
+	chunks  []string
+	emit    func(candidate) error
+}
+
+func (d *sortDeduper) Add(c candidate) error {
+	d.chunk = append(d.chunk, c)
+	if len(d.chunk) >= sortChunkWords {
+		return d.spill()
+	}
+	return nil
+}
+
+func (d *sortDeduper) spill() error {
+	if d.dir == "" {
+		dir, err := os.MkdirTemp(d.tempDir, "wordlistgen-*")
+		if err != nil {
+			return err
+		}
+		d.dir = dir
+	}
+
+	sortCandidates(d.chunk)
+	file, err := os.CreateTemp(d.dir, "chunk-*")
+	if err != nil {
+		return err
+	}
+	d.chunks = append(d.chunks, file.Name())
+
+	w := bufio.NewWriter(file)
+	for i, c := range d.chunk {
+		if i > 0 && c.Word == d.chunk[i-1].Word {
+			continue
+		}
+		if _, err := w.WriteString(encodeCandidate(c) + "\n"); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	d.chunk = d.chunk[:0]
+	return file.Close()
+}
+
+func (d *sortDeduper) Close() error {
+	if d.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(d.dir)
+}
+
+func (d *sortDeduper) Flush() error {
+	if len(d.chunks) == 0 {
+		sortCandidates(d.chunk)
+		for i, c := range d.chunk {
+			if i > 0 && c.Word == d.chunk[i-1].Word {
+				continue
+			}
+			if err := d.emit(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(d.chunk) > 0 {
+		if err := d.spill(); err != nil {
+			return err
+		}
+	}
+	return d.merge()
+}
+
+func (d *sortDeduper) merge() error {
+	h := &chunkHeap{}
+	for i, name := range d.chunks {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		if scanner.Scan() {
+			heap.Push(h, &chunkReader{scanner: scanner, chunk: i, c: decodeCandidate(scanner.Text())})
+		} else if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	previous := ""
+	first := true
+	for h.Len() > 0 {
+		r := (*h)[0]
+		if first || r.c.Word != previous {
+			if err := d.emit(r.c); err != nil {
+				return err
+			}
+			previous = r.c.Word
+			first = false
+		}
+		if r.scanner.Scan() {
+			r.c = decodeCandidate(r.scanner.Text())
+			heap.Fix(h, 0)
+		} else {
+			if err := r.scanner.Err(); err != nil {
+				return err
+			}
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// sortCandidates sorts by word, keeping the first of equal words first so
+// the provenance kept for a duplicate is the same as with the other strategies.
+func sortCandidates(candidates []candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Word < candidates[j].Word
+	})
+}
+
+// encodeCandidate writes a candidate as a single line for the temporary
+// chunk files. Fields are separated by NUL and list items by the unit
+// separator, neither of which can be typed into a word.
+func encodeCandidate(c candidate) string {
+	score, guesses, rating := "", "", ""
+	if c.Score != nil {
+		score = strconv.FormatFloat(*c.Score, 'g', -1, 64)
+	}
+	if c.Strength != nil {
+		guesses = strconv.FormatFloat(c.Strength.Guesses, 'g', -1, 64)
+		rating = strconv.Itoa(c.Strength.Score)
+	}
+	return strings.Join([]string{
+		c.Word,
+		strings.Join(c.Sources, "\x1f"),
+		strings.Join(c.Transforms, "\x1f"),
+		score,
+		guesses,
+		rating,
+	}, "\x00")
+}
+
+func decodeCandidate(line string) candidate {
+	fields := strings.SplitN(line, "\x00", 6)
+	for len(fields) < 6 {
+		fields = append(fields, "")
+	}
+	c := candidate{Word: fields[0]}
+	if fields[1] != "" {
+		c.Sources = strings.Split(fields[1], "\x1f")
+	}
+	if fields[2] != "" {
+		c.Transforms = strings.Split(fields[2], "\x1f")
+	}
+	if fields[3] != "" {
+		score, _ := strconv.ParseFloat(fields[3], 64)
+		c.Score = &score
+	}
+	if fields[4] != "" {
+		guesses, _ := strconv.ParseFloat(fields[4], 64)
+		rating, _ := strconv.Atoi(fields[5])
+		c.Strength = &strength.Result{Guesses: guesses, Score: rating}
+	}
+	return c
+}
+
+type chunkReader struct {
+	scanner *bufio.Scanner
+	// chunk is the index of the chunk, equal words of earlier chunks were
+	// added first.
+	chunk int
+	c     candidate
+}
+
+type chunkHeap []*chunkReader
+
+func (h chunkHeap) Len() int { return len(h) }
+func (h chunkHeap) Less(i, j int) bool {
+	if h[i].c.Word != h[j].c.Word {
+		return h[i].c.Word < h[j].c.Word
+	}
+	return h[i].chunk < h[j].chunk
+}
+func (h chunkHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *chunkHeap) Push(x any)   { *h = append(*h, x.(*chunkReader)) }
+func (h *chunkHeap) Pop() any {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
+
+// bloomDeduper uses a fixed amount of memory per word but may drop a small
+// share of words that were never seen, bounded by the false positive rate.
+type bloomDeduper struct {
+	filter *scalableBloom
+	emit   func(candidate) error
+}
+
+func (d *bloomDeduper) Add(c candidate) error {
+	if d.filter.testAndAdd(c.Word) {
+		return nil
+	}
+	return d.emit(c)
+}
+
+func (d *bloomDeduper) Flush() error {
+	return nil
+}
+
+func (d *bloomDeduper) Close() error {
+	return nil
+}
+
+type bloomFilter struct {
+	bits     []uint64
+	m        uint64
+	k        uint64
+	count    uint64
+	capacity uint64
+}
+
+func newBloomFilter(capacity uint64, rate float64) *bloomFilter {
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Max(1, math.Round(float64(m)/float64(capacity)*math.Ln2)))
+	return &bloomFilter{
+		bits:     make([]uint64, (m+63)/64),
+		m:        m,
+		k:        k,
+		capacity: capacity,
+	}
+}
+
+func (f *bloomFilter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *bloomFilter) add(h1, h2 uint64) {
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+	f.count++
+}
+
+// scalableBloom chains bloom filters of growing capacity and tightening
+// false positive rates so the total rate stays below the requested one
+// without knowing the number of words in advance.
+type scalableBloom struct {
+	filters []*bloomFilter
+	rate    float64
+}
+
+func newScalableBloom(rate float64) *scalableBloom {
+	s := &scalableBloom{rate: rate}
+	s.filters = []*bloomFilter{newBloomFilter(bloomInitialCapacity, rate/2)}
+	return s
+}
+
+func bloomHashes(word string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(word))
+	h1 := h.Sum64()
+	h2 := (h1 >> 33) | 1
+	return h1, h2 * 0x9e3779b97f4a7c15
+}
+
+func (s *scalableBloom) testAndAdd(word string) bool {
+	h1, h2 := bloomHashes(word)
+	for _, f := range s.filters {
+		if f.test(h1, h2) {
+			return true
+		}
+	}
+
+	last := s.filters[len(s.filters)-1]
+	if last.count >= last.capacity {
+		rate := s.rate / math.Pow(2, float64(len(s.filters)+1))
+		last = newBloomFilter(last.capacity*2, rate)
+		s.filters = append(s.filters, last)
+	}
+	last.add(h1, h2)
+	return false
+}
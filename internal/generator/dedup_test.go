@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// duplicated returns words with many duplicates, each with the index it
+// was made at as its source.
+func duplicated(words int) []candidate {
+	var candidates []candidate
+	for i := range words {
+		candidates = append(candidates, newCandidate(fmt.Sprintf("w%d", i*7%(words/2)), []string{fmt.Sprint(i)}))
+	}
+	return candidates
+}
+
+// firstSeen returns the first candidate of every word, in order.
+func firstSeen(candidates []candidate) []candidate {
+	seen := make(map[string]struct{})
+	var result []candidate
+	for _, c := range candidates {
+		if _, ok := seen[c.Word]; !ok {
+			seen[c.Word] = struct{}{}
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func dedupAll(t *testing.T, opts Options, candidates []candidate) []candidate {
+	t.Helper()
+	var result []candidate
+	d, err := newDeduper(opts, func(c candidate) error {
+		result = append(result, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	for _, c := range candidates {
+		if err := d.Add(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestDedup(t *testing.T) {
+	chunk, capacity := sortChunkWords, bloomInitialCapacity
+	t.Cleanup(func() { sortChunkWords, bloomInitialCapacity = chunk, capacity })
+
+	candidates := duplicated(20000)
+	want := firstSeen(candidates)
+	sorted := slices.Clone(want)
+	sortCandidates(sorted)
+
+	tests := []struct {
+		name     string
+		dedup    string
+		chunk    int
+		capacity uint64
+		want     []candidate
+		// missing is the most words the strategy may drop.
+		missing int
+	}{
+		{name: "map", dedup: DedupMap, want: want},
+		{name: "sort in memory", dedup: DedupSort, chunk: 1 << 20, want: sorted},
+		{name: "sort spilled", dedup: DedupSort, chunk: 512, want: sorted},
+		{name: "bloom", dedup: DedupBloom, capacity: 1 << 20, want: want},
+		{name: "bloom grown", dedup: DedupBloom, capacity: 256, want: want, missing: len(want) / 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortChunkWords, bloomInitialCapacity = chunk, capacity
+			if tt.chunk > 0 {
+				sortChunkWords = tt.chunk
+			}
+			if tt.capacity > 0 {
+				bloomInitialCapacity = tt.capacity
+			}
+
+			got := dedupAll(t, Options{Dedup: tt.dedup, TempDir: t.TempDir()}, candidates)
+			if tt.missing == 0 {
+				if !slices.EqualFunc(got, tt.want, sameCandidate) {
+					t.Errorf("got %d words, want %d in the same order with the first provenance", len(got), len(tt.want))
+				}
+				return
+			}
+
+			// A bloom filter may drop words, never reorder or repeat them.
+			i := 0
+			for _, c := range got {
+				for i < len(tt.want) && !sameCandidate(tt.want[i], c) {
+					i++
+				}
+				if i == len(tt.want) {
+					t.Fatalf("%s is out of order or repeated", c.Word)
+				}
+			}
+			if dropped := len(tt.want) - len(got); dropped > tt.missing {
+				t.Errorf("dropped %d of %d words, want at most %d", dropped, len(tt.want), tt.missing)
+			}
+		})
+	}
+}
+
+func sameCandidate(a, b candidate) bool {
+	return a.Word == b.Word && slices.Equal(a.Sources, b.Sources) && slices.Equal(a.Transforms, b.Transforms)
+}
+
+func TestDedupStrategiesAgree(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "plain", opts: Options{}},
+		{name: "leet and caps", opts: Options{EnableLeet: true, EnableCapitalize: true}},
+		{name: "templates", opts: Options{EnableTemplates: true, Separators: []string{"_"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outputs [][]string
+			for _, dedup := range []string{DedupMap, DedupSort, DedupBloom} {
+				opts := tt.opts
+				opts.InputFirstName = []string{"John"}
+				opts.InputLastName = []string{"Doe"}
+				opts.InputBirthday = []string{"03", "07", "1991"}
+				opts.InputRelatedWords = []string{"rex", "blue", "ankara"}
+				opts.Dedup = dedup
+				opts.TempDir = t.TempDir()
+				opts.OutputFilePath = filepath.Join(t.TempDir(), "out.txt")
+				if err := Run(context.Background(), opts, nil); err != nil {
+					t.Fatalf("%s: %v", dedup, err)
+				}
+				lines := readOutput(t, opts)
+				if dedup == DedupSort && !slices.IsSorted(lines) {
+					t.Errorf("sort dedup output is not sorted")
+				}
+				slices.Sort(lines)
+				outputs = append(outputs, lines)
+			}
+			if !slices.Equal(outputs[0], outputs[1]) {
+				t.Errorf("map and sort dedup differ: %d and %d words", len(outputs[0]), len(outputs[1]))
+			}
+			if !slices.Equal(outputs[0], outputs[2]) {
+				t.Errorf("map and bloom dedup differ: %d and %d words", len(outputs[0]), len(outputs[2]))
+			}
+			if unique := slices.Compact(slices.Clone(outputs[0])); len(unique) != len(outputs[0]) {
+				t.Errorf("map dedup output has %d duplicates", len(outputs[0])-len(unique))
+			}
+		})
+	}
+}
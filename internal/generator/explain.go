@@ -0,0 +1,284 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+)
+
+// Explanation describes how a password is generated from a profile or, when
+// it is not, the closest candidate that is.
+type Explanation struct {
+	Password string
+	// Found reports whether Password itself is generated.
+	Found bool
+	// Candidate is Password when found, otherwise the closest candidate.
+	Candidate  string
+	Distance   int
+	Sources    []Origin
+	Transforms []string
+	Derivation string
+	// Outline is Derivation without the tokens and leet substitutions, only
+	// the profile fields and transforms, for reports that must not reveal
+	// the password, e.g. "first name + birthday → leet".
+	Outline string
+	// InLengthRange reports whether Candidate passes the length filter.
+	InLengthRange bool
+	// StrongEnough reports whether Candidate passes the strength filter.
+	StrongEnough bool
+	// Rank is the 1 based position of Candidate in the wordlist, or 0 when
+	// it is filtered out.
+	Rank int64
+}
+
+// Origin is an input token a candidate was built from and the profile field
+// it came from, if any.
+type Origin struct {
+	Token string
+	Field string
+}
+
+var errFound = errors.New("found")
+
+// Explain searches the candidates generated from opts for password and
+// returns its derivation, or the nearest miss by edit distance. Sharding is
+// ignored. Candidates the length and strength filters drop are still
+// searched, but are not ranked.
+func Explain(opts Options, password string) (Explanation, error) {
+	g, err := prepare(opts)
+	if err != nil {
+		return Explanation{}, err
+	}
+	// The strength filter is applied here rather than by the jobs, so that
+	// a password it drops is reported as such and not as a miss.
+	g.rateStrength = false
+	strongEnough := func(word string) bool {
+		return opts.MinStrength == 0 || strength.Estimate(word).Score >= opts.MinStrength
+	}
+	listed := func(word string) bool {
+		return inLengthRange(word, g.minLength, g.maxLength) && strongEnough(word)
+	}
+
+	// With ranking the position of a word is only known once every
+	// candidate was scored, so the search cannot stop early.
+	ranked := g.model != nil && opts.MarkovRank
+
+	var (
+		best       candidate
+		bestRank   int64
+		distance   = -1
+		seen       = make(map[string]struct{})
+		rank       int64
+		candidates []candidate
+	)
+
+	jobs := buildJobs(opts, g, func(string) bool { return true })
+	err = runJobs(context.Background(), jobs, opts.Workers, func(batch []candidate) error {
+		for _, c := range batch {
+			if _, ok := seen[c.Word]; ok {
+				continue
+			}
+			seen[c.Word] = struct{}{}
+
+			inList := listed(c.Word)
+			if inList {
+				rank++
+				if ranked {
+					candidates = append(candidates, c)
+				}
+			}
+
+			if distance != 0 {
+				d := editDistance(password, c.Word, distance)
+				if distance < 0 || d < distance {
+					best, distance = c, d
+					bestRank = 0
+					if inList {
+						bestRank = rank
+					}
+				}
+			}
+			if distance == 0 && !ranked {
+				return errFound
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errFound {
+		return Explanation{}, err
+	}
+	if distance < 0 {
+		return Explanation{}, fmt.Errorf("the profile does not generate any candidates")
+	}
+
+	if ranked && listed(best.Word) {
+		rankByScore(candidates)
+		bestRank = int64(slices.IndexFunc(candidates, func(c candidate) bool {
+			return c.Word == best.Word
+		})) + 1
+	}
+
+	e := Explanation{
+		Password:      password,
+		Found:         distance == 0,
+		Candidate:     best.Word,
+		Distance:      distance,
+		Transforms:    best.Transforms,
+		InLengthRange: inLengthRange(best.Word, g.minLength, g.maxLength),
+		StrongEnough:  strongEnough(best.Word),
+		Rank:          bestRank,
+	}
+	for _, token := range best.Sources {
+		field := tokenField(opts, token)
+		if field == "" {
+			field = g.origins[token]
+		}
+		e.Sources = append(e.Sources, Origin{Token: token, Field: field})
+	}
+	e.Derivation = describeDerivation(e.Sources, best, leetTable(g.transforms))
+	e.Outline = describeOutline(e.Sources, best)
+	return e, nil
+}
+
+// tokenField returns the profile field a token comes from.
+func tokenField(opts Options, token string) string {
+	matches := func(list []string) bool {
+		for _, w := range list {
+			if token == w || token == capitalize(w) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case matches(opts.InputFirstName):
+		return "first name"
+	case matches(opts.InputLastName):
+		return "last name"
+	case matches(opts.InputRelatedWords):
+		return "related word"
+	case matches(opts.InputBirthday) || token == strings.Join(opts.InputBirthday, ""):
+		return "birthday"
+	case slices.Contains(templateSymbols, token):
+		return "symbol"
+	case slices.Contains(templateNumbers, token) || slices.Contains(templateDigits, token):
+		return "number"
+	}
+
+	day, month, year := splitBirthday(opts.InputBirthday)
+	if token != "" && (token == day || token == month || token == year || slices.Contains(shortYear(year), token)) {
+		return "birthday"
+	}
+	return ""
+}
+
+// describeDerivation renders sources and transforms as a single line, e.g.
+// "john (first name) + doe (last name) → combine → leet (o→0, e→3)", with
+// the leet substitutions found in table.
+func describeDerivation(sources []Origin, c candidate, table map[rune]string) string {
+	tokens := make([]string, 0, len(sources))
+	for _, s := range sources {
+		if s.Field != "" {
+			tokens = append(tokens, fmt.Sprintf("%s (%s)", s.Token, s.Field))
+		} else {
+			tokens = append(tokens, s.Token)
+		}
+	}
+
+	parts := []string{strings.Join(tokens, " + ")}
+	for _, t := range c.Transforms {
+		if t == "leet" {
+			t = describeLeet(c, table)
+		}
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// describeOutline renders the fields of sources and the transforms as a
+// single line, like describeDerivation without the tokens. Tokens of no
+// known field are shown as "word".
+func describeOutline(sources []Origin, c candidate) string {
+	fields := make([]string, 0, len(sources))
+	for _, s := range sources {
+		if s.Field != "" {
+			fields = append(fields, s.Field)
+		} else {
+			fields = append(fields, "word")
+		}
+	}
+	return strings.Join(append([]string{strings.Join(fields, " + ")}, c.Transforms...), " → ")
+}
+
+// leetTable returns the substitutions of the leet transform among list.
+func leetTable(list []Transform) map[rune]string {
+	for _, t := range list {
+		if leet, ok := t.(leetTransform); ok {
+			return leet.substitutions()
+		}
+	}
+	return leetMap
+}
+
+// describeLeet lists the substitutions of table that show up in the
+// candidate.
+func describeLeet(c candidate, table map[rune]string) string {
+	base := strings.ToLower(strings.Join(c.Sources, ""))
+	chars := slices.Sorted(maps.Keys(table))
+	var subs []string
+	for _, char := range chars {
+		leet := table[char]
+		if strings.ContainsRune(base, char) && strings.Contains(c.Word, leet) {
+			subs = append(subs, fmt.Sprintf("%c→%s", char, leet))
+		}
+	}
+	if len(subs) == 0 {
+		return "leet"
+	}
+	return fmt.Sprintf("leet (%s)", strings.Join(subs, ", "))
+}
+
+// editDistance returns the Levenshtein distance between a and b. Once the
+// distance is known to be at least limit, it returns early with a value of
+// at least limit. A negative limit means no limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if limit >= 0 {
+		diff := len(ra) - len(rb)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff >= limit {
+			return diff
+		}
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if limit >= 0 && rowMin >= limit {
+			return rowMin
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
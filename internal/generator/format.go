@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatPlain = "plain"
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// format turns candidates into output lines. header, if set, is written at
+// the start of every output file. strength is set by formats that include
+// the strength estimate of every candidate.
+type format struct {
+	ext      string
+	header   string
+	line     func(candidate) string
+	strength bool
+}
+
+var formats = map[string]format{
+	FormatPlain: {
+		ext:  ".txt",
+		line: func(c candidate) string { return c.Word },
+	},
+	FormatJSONL: {
+		ext:      ".jsonl",
+		line:     jsonLine,
+		strength: true,
+	},
+	FormatCSV: {
+		ext:      ".csv",
+		header:   "password,sources,transforms,score,strength,guesses_log10",
+		line:     csvLine,
+		strength: true,
+	},
+}
+
+// validateFormat checks the output format of opts, if set.
+func validateFormat(opts Options) error {
+	if _, ok := formats[opts.Format]; opts.Format != "" && !ok {
+		return fmt.Errorf("unknown output format %q (use %s, %s or %s)", opts.Format, FormatPlain, FormatJSONL, FormatCSV)
+	}
+	return nil
+}
+
+// formatOf returns the format matching the extension of path.
+func formatOf(path string) string {
+	switch filepath.Ext(path) {
+	case formats[FormatJSONL].ext:
+		return FormatJSONL
+	case formats[FormatCSV].ext:
+		return FormatCSV
+	}
+	return FormatPlain
+}
+
+// WordlistFormat returns the format of the wordlist at path, judging by
+// its extension once any compression extension is left out.
+func WordlistFormat(path string) string {
+	for _, c := range compressions {
+		if strings.HasSuffix(path, c.ext) {
+			path = strings.TrimSuffix(path, c.ext)
+			break
+		}
+	}
+	return formatOf(path)
+}
+
+// ScanPasswords calls fn for every password of a wordlist in format, with
+// the line it was read from. The header of a CSV wordlist is skipped. If fn
+// returns an error scanning stops and that error is returned.
+func ScanPasswords(r io.Reader, format string, fn func(password, line string) error) error {
+	header := formats[format].header
+	first := true
+	return ScanWordlist(r, func(line string) error {
+		if first {
+			first = false
+			if header != "" && line == header {
+				return nil
+			}
+		}
+		password, err := parsePassword(format, line)
+		if err != nil {
+			return err
+		}
+		return fn(password, line)
+	})
+}
+
+func parsePassword(format, line string) (string, error) {
+	switch format {
+	case FormatJSONL:
+		var r record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return "", fmt.Errorf("invalid JSON line %q: %w", line, err)
+		}
+		return r.Password, nil
+	case FormatCSV:
+		fields, err := csv.NewReader(strings.NewReader(line)).Read()
+		if err != nil {
+			return "", fmt.Errorf("invalid CSV line %q: %w", line, err)
+		}
+		return fields[0], nil
+	}
+	return line, nil
+}
+
+// resolveFormat returns the output format: the one asked for, or when none
+// was asked for, the one matching the output file extension.
+func resolveFormat(opts Options, compress string) (format, error) {
+	if opts.Format == "" {
+		path := OutputPath(opts)
+		if c, ok := compressions[compress]; ok {
+			path = strings.TrimSuffix(path, c.ext)
+		}
+		return formats[formatOf(path)], nil
+	}
+	if err := validateFormat(opts); err != nil {
+		return format{}, err
+	}
+	return formats[opts.Format], nil
+}
+
+type record struct {
+	Password     string   `json:"password"`
+	Sources      []string `json:"sources"`
+	Transforms   []string `json:"transforms"`
+	Score        *float64 `json:"score,omitempty"`
+	Strength     *int     `json:"strength,omitempty"`
+	GuessesLog10 *float64 `json:"guesses_log10,omitempty"`
+}
+
+func jsonLine(c candidate) string {
+	r := record{
+		Password:   c.Word,
+		Sources:    c.Sources,
+		Transforms: c.Transforms,
+		Score:      c.Score,
+	}
+	if c.Strength != nil {
+		guesses := math.Round(c.Strength.Log10()*100) / 100
+		r.Strength = &c.Strength.Score
+		r.GuessesLog10 = &guesses
+	}
+	if r.Sources == nil {
+		r.Sources = []string{}
+	}
+	if r.Transforms == nil {
+		r.Transforms = []string{}
+	}
+	data, _ := json.Marshal(r)
+	return string(data)
+}
+
+// csvLine joins the sources with + and the transforms with > in the order
+// they were applied.
+func csvLine(c candidate) string {
+	score := ""
+	if c.Score != nil {
+		score = strconv.FormatFloat(*c.Score, 'f', 4, 64)
+	}
+	rating, guesses := "", ""
+	if c.Strength != nil {
+		rating = strconv.Itoa(c.Strength.Score)
+		guesses = strconv.FormatFloat(c.Strength.Log10(), 'f', 2, 64)
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{c.Word, strings.Join(c.Sources, "+"), strings.Join(c.Transforms, ">"), score, rating, guesses})
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
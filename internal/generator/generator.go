@@ -1,200 +1,540 @@
 package generator
 
 import (
-	"os"
-	"strconv"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
 	"strings"
-	"unicode"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/markov"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+)
+
+const (
+	markovSuffixMaxLength = 4
+	defaultMinLength      = 6
+	defaultMaxLength      = 12
+	defaultCombineDepth   = 3
+	// MaxCombineDepth is the most input words combined into one password.
+	MaxCombineDepth = 4
+	// maxJobCombinations is the most combinations a job holds in memory,
+	// before transforms. The combinations of an input word that make more
+	// are split into several jobs.
+	maxJobCombinations = 1 << 16
 )
 
 type Options struct {
-	InputFirstName    []string
-	InputLastName     []string
-	InputBirthday     []string
-	InputRelatedWords []string
-	InputMinLength    string
-	InputMaxLength    string
-	OutputFilePath    string
-	EnableLeet        bool
-	EnableCapitalize  bool
+	InputFirstName         []string
+	InputLastName          []string
+	InputBirthday          []string
+	InputRelatedWords      []string
+	MinLength              int
+	MaxLength              int
+	OutputFilePath         string
+	EnableLeet             bool
+	EnableCapitalize       bool
+	Transforms             []string
+	MarkovModelPath        string
+	MarkovSuffixes         int
+	MarkovRank             bool
+	EnableTemplates        bool
+	TemplateFilePath       string
+	Workers                int
+	Dedup                  string
+	BloomFalsePositiveRate float64
+	TempDir                string
+	Resume                 bool
+	StateFilePath          string
+	ShardIndex             int
+	ShardCount             int
+	Compress               string
+	SplitLines             int
+	SplitMegabytes         int
+	Format                 string
+	MinStrength            int
+	// Sources add the tokens of registered sources after those of the
+	// inputs above.
+	Sources []SourceSpec
+	// Separators join combined words, besides nothing, e.g. "_" for
+	// john_doe next to johndoe. CombineDepth is the most words combined,
+	// 3 when zero.
+	Separators   []string
+	CombineDepth int
 }
 
-var leetMap = map[rune]string{
-	'a': "4",
-	'e': "3",
-	'i': "1",
-	'o': "0",
-	's': "5",
+// generation holds what a run enumerates candidates from.
+type generation struct {
+	inputs []string
+	// origins maps the tokens of Options.Sources to the source they came
+	// from.
+	origins   map[string]string
+	minLength int
+	maxLength int
+	templates []template
+	model     *markov.Model
+	// transforms are applied in order to every base candidate.
+	transforms []Transform
+	// rateStrength is set when every candidate needs a strength estimate.
+	rateStrength bool
+	// jobLimit caps the base candidates of the jobs built from then on,
+	// when not zero. Only previews set it.
+	jobLimit int
+	progress *progressTracker
 }
 
-func Run(opts Options) error {
-	inputs, minLength, maxLength := collectAllInputs(opts)
-	words := []string{}
-	words = append(words, inputs...)
-
-	for n := 2; n <= 3; n++ {
-		words = append(words, combineWordsN(inputs, n)...)
+// Validate checks the options that do not depend on any file. Zero lengths
+// stand for the defaults.
+func (opts Options) Validate() error {
+	if opts.MinLength < 0 || opts.MaxLength < 0 {
+		return fmt.Errorf("password length must be a positive number")
 	}
-
-	if opts.EnableLeet {
-		words = append(words, leetVariants(words)...)
+	if err := ValidateLengthRange(lengthRange(opts)); err != nil {
+		return err
+	}
+	if opts.CombineDepth != 0 {
+		if err := ValidateCombineDepth(opts.CombineDepth); err != nil {
+			return err
+		}
+	}
+	if err := ValidateMarkovSuffixes(opts.MarkovSuffixes); err != nil {
+		return err
+	}
+	if err := ValidateMinStrength(opts.MinStrength); err != nil {
+		return err
+	}
+	if err := validateDedup(opts); err != nil {
+		return err
+	}
+	if err := validateFormat(opts); err != nil {
+		return err
+	}
+	if opts.SplitLines < 0 || opts.SplitMegabytes < 0 {
+		return fmt.Errorf("split size must be a positive number")
+	}
+	if _, err := resolveTransforms(opts); err != nil {
+		return err
+	}
+	if err := validateSources(opts); err != nil {
+		return err
 	}
-	if opts.EnableCapitalize {
-		words = append(words, caseVariants(words)...)
+	return validateShard(opts.ShardIndex, opts.ShardCount)
+}
+
+// ValidateLengthRange checks that a password length range is not empty.
+func ValidateLengthRange(minLength, maxLength int) error {
+	if minLength > maxLength {
+		return fmt.Errorf("min password length (%d) cannot be greater than max password length (%d)", minLength, maxLength)
 	}
+	return nil
+}
 
-	words = removeDuplicates(words)
-	words = filterWordsByLength(words, minLength, maxLength)
+// ValidateCombineDepth checks the most input words combined into one
+// password.
+func ValidateCombineDepth(depth int) error {
+	if depth < 1 || depth > MaxCombineDepth {
+		return fmt.Errorf("combination depth must be between 1 and %d, got %d", MaxCombineDepth, depth)
+	}
+	return nil
+}
 
-	err := saveToFile(words, opts.OutputFilePath)
-	if err != nil {
-		return err
+// ValidateMarkovSuffixes checks the number of Markov suffixes per word.
+func ValidateMarkovSuffixes(n int) error {
+	if n < 0 {
+		return fmt.Errorf("markov suffixes cannot be negative, got %d", n)
 	}
 	return nil
 }
 
-func capitalize(word string) string {
-	if len(word) == 0 {
-		return ""
+// ValidateMinStrength checks a minimum strength score.
+func ValidateMinStrength(score int) error {
+	if score < 0 || score > 4 {
+		return fmt.Errorf("minimum strength must be between 0 and 4, got %d", score)
 	}
-	return strings.ToUpper(string(word[0])) + strings.ToLower(word[1:])
+	return nil
 }
 
-func collectAllInputs(opts Options) ([]string, int, int) {
-	words := []string{}
+func prepare(opts Options) (*generation, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generation{rateStrength: opts.MinStrength > 0}
+	g.minLength, g.maxLength = lengthRange(opts)
 
-	appendWithCap := func(list []string) {
-		for _, w := range list {
-			words = append(words, w)
-			capW := capitalize(w)
-			if capW != w {
-				words = append(words, capW)
-			}
+	var err error
+	g.inputs, g.origins, err = collectTokens(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	g.transforms, err = resolveTransforms(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	g.templates, err = collectTemplates(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MarkovModelPath != "" {
+		g.model, err = markov.LoadFile(opts.MarkovModelPath)
+		if err != nil {
+			return nil, err
 		}
 	}
+	return g, nil
+}
 
-	appendWithCap(opts.InputFirstName)
-	appendWithCap(opts.InputLastName)
-	appendWithCap(opts.InputRelatedWords)
+// Run writes the wordlist of opts to its output file. It stops with the
+// context error once ctx is done, leaving the state file of a resumable run
+// behind. If progress is not nil, it is called from time to time while
+// generating and once more when done.
+func Run(ctx context.Context, opts Options, progress func(Progress)) error {
+	g, err := prepare(opts)
+	if err != nil {
+		return err
+	}
+	g.progress = newProgressTracker(progress)
 
-	if len(opts.InputBirthday) > 0 {
-		fullBirthday := strings.Join(opts.InputBirthday, "")
-		words = append(words, fullBirthday)
-		words = append(words, opts.InputBirthday...)
+	compress, err := resolveCompression(opts)
+	if err != nil {
+		return err
+	}
+
+	f, err := resolveFormat(opts, compress)
+	if err != nil {
+		return err
 	}
+	g.rateStrength = g.rateStrength || f.strength
 
-	minLength := 6
-	maxLength := 12
-	if opts.InputMinLength != "" {
-		minLength, _ = strconv.Atoi(opts.InputMinLength)
+	out, err := openCheckpointSink(opts, compress, f)
+	if err != nil {
+		return err
 	}
-	if opts.InputMaxLength != "" {
-		maxLength, _ = strconv.Atoi(opts.InputMaxLength)
+	if err := generate(ctx, opts, g, out); err != nil {
+		out.Close()
+		return err
 	}
-	return words, minLength, maxLength
+	return out.finish()
 }
 
-func combineWordsN(words []string, n int) []string {
-	var result []string
-	var combine func(word []string, used []bool)
-	combine = func(word []string, used []bool) {
-		if len(word) == n {
-			combined := ""
-			for _, w := range word {
-				combined += w
-			}
-			result = append(result, combined)
-			return
+// Each runs the generation like Run but passes every password to fn instead
+// of writing it to a file. If fn returns an error or ctx is done, generation
+// stops and Each returns that error.
+func Each(ctx context.Context, opts Options, fn func(password string) error) error {
+	g, err := prepare(opts)
+	if err != nil {
+		return err
+	}
+	return generate(ctx, opts, g, funcSink(fn))
+}
+
+// EachCandidate is like Each but passes every password with its provenance.
+func EachCandidate(ctx context.Context, opts Options, fn func(Candidate) error) error {
+	g, err := prepare(opts)
+	if err != nil {
+		return err
+	}
+	return generate(ctx, opts, g, candidateSink(fn))
+}
+
+// WriteTo runs the generation like Run but writes the wordlist to w, in the
+// format of opts (plain unless set). Compression, splitting and resuming do
+// not apply.
+func WriteTo(ctx context.Context, opts Options, w io.Writer) error {
+	g, err := prepare(opts)
+	if err != nil {
+		return err
+	}
+	f, err := resolveFormat(opts, CompressNone)
+	if err != nil {
+		return err
+	}
+	g.rateStrength = g.rateStrength || f.strength
+
+	out := newWriterSink(w, f)
+	if err := generate(ctx, opts, g, out); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// generate enumerates the candidates of g, filters and dedups them and
+// writes the result to out, which it does not close.
+func generate(ctx context.Context, opts Options, g *generation, out sink) error {
+	// Ranking needs every word before anything can be written.
+	var ranked []candidate
+	write := func(c candidate) error {
+		g.progress.wrote()
+		return out.Write(c)
+	}
+	if g.model != nil && opts.MarkovRank {
+		write = func(c candidate) error {
+			ranked = append(ranked, c)
+			return nil
 		}
-		for i, w := range words {
-			if !used[i] {
-				used[i] = true
-				combine(append(word, w), used)
-				used[i] = false
+	}
+
+	dedup, err := newDeduper(opts, write)
+	if err != nil {
+		return err
+	}
+	defer dedup.Close()
+
+	keep := func(word string) bool {
+		return inLengthRange(word, g.minLength, g.maxLength) && inShard(word, opts.ShardIndex, opts.ShardCount)
+	}
+	jobs := buildJobs(opts, g, keep)
+	if g.progress != nil {
+		g.progress.jobs = len(jobs)
+	}
+	err = runJobs(ctx, jobs, opts.Workers, func(batch []candidate) error {
+		for _, c := range batch {
+			if err := dedup.Add(c); err != nil {
+				return err
 			}
 		}
+		g.progress.jobDone()
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	used := make([]bool, len(words))
-	combine([]string{}, used)
-	return removeDuplicates(result)
-}
-
-func leetVariants(words []string) []string {
-	var result []string
-	for _, word := range words {
-		leetWord := ""
-		for _, char := range word {
-			lowerChar := unicode.ToLower(char)
-			if leetChar, ok := leetMap[lowerChar]; ok {
-				leetWord += leetChar
-			} else {
-				leetWord += string(char)
+	if err := dedup.Flush(); err != nil {
+		return err
+	}
+
+	if ranked != nil {
+		rankByScore(ranked)
+		for _, c := range ranked {
+			g.progress.wrote()
+			if err := out.Write(c); err != nil {
+				return err
 			}
 		}
-		if leetWord != word {
-			result = append(result, leetWord)
-		}
 	}
-	return removeDuplicates(result)
+	g.progress.finish()
+	return nil
 }
 
-func caseVariants(words []string) []string {
-	var result []string
-	for _, word := range words {
-		result = append(result, word)
-		upperWord := ""
-		for _, char := range word {
-			if unicode.IsLower(char) {
-				upperWord += string(unicode.ToUpper(char))
-			} else {
-				upperWord += string(unicode.ToLower(char))
+// buildJobs splits the generation into jobs in a fixed order: one per input
+// word for the word itself and the combinations starting with it, or more
+// when they are over maxJobCombinations, one per template and one per input
+// word for its Markov extensions. Only the words keep accepts, and that are
+// at least opts.MinStrength strong, are returned by the jobs.
+func buildJobs(opts Options, g *generation, keep func(string) bool) []job {
+	inputs, model := g.inputs, g.model
+	depth := opts.CombineDepth
+	if depth == 0 {
+		depth = defaultCombineDepth
+	}
+	separators := append([]string{""}, opts.Separators...)
+	limit := g.jobLimit
+	expand := func(candidates []candidate) []candidate {
+		candidates = applyTransforms(candidates, g.transforms)
+		produced := len(candidates)
+		kept := candidates[:0]
+		for _, c := range candidates {
+			if !keep(c.Word) {
+				continue
+			}
+			if g.rateStrength {
+				rating := strength.Estimate(c.Word)
+				if rating.Score < opts.MinStrength {
+					continue
+				}
+				c.Strength = &rating
+			}
+			if model != nil {
+				score := model.Score(c.Word)
+				c.Score = &score
 			}
+			kept = append(kept, c)
 		}
-		if upperWord != word {
-			result = append(result, upperWord)
+		g.progress.expanded(produced, len(kept))
+		return kept
+	}
+
+	// combine returns the input word i followed by its combinations, from
+	// the one at start up to the one before end in that sequence.
+	combine := func(i, start, end int) []candidate {
+		var candidates []candidate
+		if start == 0 {
+			candidates = append(candidates, newCandidate(inputs[i], inputs[i:i+1]))
 		}
+		offset := 1
+		for n := 2; n <= depth && offset < end; n++ {
+			count := arrangements(len(inputs)-1, n-1) * len(separators)
+			if start < offset+count {
+				skip := max(start-offset, 0)
+				limit := min(end, offset+count) - offset - skip
+				candidates = append(candidates, combineWordsN(inputs, i, n, separators, skip, limit)...)
+			}
+			offset += count
+		}
+		return candidates
 	}
-	return removeDuplicates(result)
-}
 
-func removeDuplicates(words []string) []string {
-	seen := make(map[string]struct{})
-	result := []string{}
-	for _, word := range words {
-		if _, ok := seen[word]; !ok {
-			seen[word] = struct{}{}
-			result = append(result, word)
+	var jobs []job
+	total := 1 + jobCombinations(opts, len(inputs))
+	for i := range inputs {
+		if limit > 0 {
+			jobs = append(jobs, func() []candidate {
+				return expand(combine(i, 0, limit))
+			})
+			continue
+		}
+		for start := 0; start < total; start += maxJobCombinations {
+			end := min(start+maxJobCombinations, total)
+			jobs = append(jobs, func() []candidate {
+				return expand(combine(i, start, end))
+			})
 		}
 	}
-	return result
-}
 
-func filterWordsByLength(words []string, minLength, maxLength int) []string {
-	filtered := []string{}
-	for _, word := range words {
-		if len(word) >= minLength && len(word) <= maxLength {
-			filtered = append(filtered, word)
+	p := newProfile(opts)
+	for _, t := range g.templates {
+		jobs = append(jobs, func() []candidate {
+			return expand(removeDuplicateCandidates(t.expand(p)))
+		})
+	}
+
+	if model != nil {
+		for _, input := range inputs {
+			jobs = append(jobs, func() []candidate {
+				return expand(markovExtensions(model, input, opts.MarkovSuffixes))
+			})
 		}
 	}
-	return filtered
+	return jobs
+}
+
+func capitalize(word string) string {
+	if len(word) == 0 {
+		return ""
+	}
+	return strings.ToUpper(string(word[0])) + strings.ToLower(word[1:])
 }
 
-func saveToFile(words []string, filepath string) error {
-	if filepath == "" {
-		filepath = "wordlist.txt"
+// lengthRange returns the password length range, with the defaults for
+// lengths left at zero.
+func lengthRange(opts Options) (int, int) {
+	minLength, maxLength := opts.MinLength, opts.MaxLength
+	if minLength == 0 {
+		minLength = defaultMinLength
+	}
+	if maxLength == 0 {
+		maxLength = defaultMaxLength
 	}
+	return minLength, maxLength
+}
 
-	file, err := os.Create(filepath)
-	if err != nil {
-		return err
+// jobCombinations returns the number of combinations of a job combining
+// one of inputs words with every arrangement of the others, once per
+// separator.
+func jobCombinations(opts Options, inputs int) int {
+	depth := opts.CombineDepth
+	if depth == 0 {
+		depth = defaultCombineDepth
 	}
-	defer file.Close()
+	separators := 1 + len(opts.Separators)
+	total := 0
+	for n := 2; n <= depth; n++ {
+		total = saturatingAdd(total, saturatingMul(arrangements(inputs-1, n-1), separators))
+	}
+	return total
+}
 
-	for _, word := range words {
-		_, err := file.WriteString(word + "\n")
-		if err != nil {
-			return err
+// arrangements returns the number of ordered picks of k out of n distinct
+// items, or math.MaxInt when that is more.
+func arrangements(n, k int) int {
+	if k > n {
+		return 0
+	}
+	result := 1
+	for i := 0; i < k; i++ {
+		result = saturatingMul(result, n-i)
+	}
+	return result
+}
+
+func saturatingMul(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// combineWordsN returns the combinations of n distinct input words that start
+// with words[first], joined with each of separators, leaving out the first
+// skip and making no more than limit.
+func combineWordsN(words []string, first, n int, separators []string, skip, limit int) []candidate {
+	var result []candidate
+	var combine func(word []string, used []bool)
+	combine = func(word []string, used []bool) {
+		if len(result) >= limit {
+			return
+		}
+		if len(word) == n {
+			sources := append([]string{}, word...)
+			for _, sep := range separators {
+				if skip > 0 {
+					skip--
+					continue
+				}
+				if len(result) == limit {
+					return
+				}
+				result = append(result, newCandidate(strings.Join(word, sep), sources, "combine"))
+			}
+			return
+		}
+		// Whole branches of skipped combinations are passed over at once.
+		branch := saturatingMul(arrangements(len(words)-len(word)-1, n-len(word)-1), len(separators))
+		for i, w := range words {
+			if used[i] {
+				continue
+			}
+			if skip >= branch {
+				skip -= branch
+				continue
+			}
+			used[i] = true
+			combine(append(word, w), used)
+			used[i] = false
 		}
 	}
-	return nil
+	used := make([]bool, len(words))
+	used[first] = true
+	combine([]string{words[first]}, used)
+	return removeDuplicateCandidates(result)
+}
+
+func markovExtensions(model *markov.Model, word string, n int) []candidate {
+	var result []candidate
+	for _, suffix := range model.Suffixes(word, n, markovSuffixMaxLength) {
+		result = append(result, newCandidate(word+suffix, []string{word}, "markov-suffix"))
+	}
+	return removeDuplicateCandidates(result)
+}
+
+// rankByScore sorts candidates by their Markov score, most likely first.
+func rankByScore(candidates []candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return *candidates[i].Score > *candidates[j].Score
+	})
+}
+
+func inLengthRange(word string, minLength, maxLength int) bool {
+	return len(word) >= minLength && len(word) <= maxLength
 }
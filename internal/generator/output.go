@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const defaultOutputFilePath = "wordlist.txt"
+
+const (
+	CompressNone = "none"
+	CompressGzip = "gzip"
+	CompressZstd = "zstd"
+	CompressXz   = "xz"
+)
+
+type compression struct {
+	ext       string
+	newWriter func(io.Writer) (io.WriteCloser, error)
+	newReader func(io.Reader) (io.Reader, error)
+}
+
+var compressions = map[string]compression{
+	CompressGzip: {
+		ext: ".gz",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	CompressZstd: {
+		ext: ".zst",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w)
+		},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return zstd.NewReader(r)
+		},
+	},
+	CompressXz: {
+		ext: ".xz",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return xz.NewWriter(w)
+		},
+		newReader: func(r io.Reader) (io.Reader, error) {
+			return xz.NewReader(r)
+		},
+	},
+}
+
+// resolveCompression returns the compression to use: the one asked for, or
+// when none was asked for, the one matching the output file extension.
+func resolveCompression(opts Options) (string, error) {
+	switch opts.Compress {
+	case "":
+		path := opts.OutputFilePath
+		for name, c := range compressions {
+			if strings.HasSuffix(path, c.ext) {
+				return name, nil
+			}
+		}
+		return CompressNone, nil
+	case CompressNone:
+		return CompressNone, nil
+	}
+	if _, ok := compressions[opts.Compress]; !ok {
+		return "", fmt.Errorf("unknown compression %q (use %s, %s, %s or %s)", opts.Compress, CompressNone, CompressGzip, CompressZstd, CompressXz)
+	}
+	return opts.Compress, nil
+}
+
+// OutputPath returns the path the wordlist is written to. A compression
+// extension is added when compression was asked for and the path lacks it.
+func OutputPath(opts Options) string {
+	path := opts.OutputFilePath
+	if path == "" {
+		path = defaultOutputFilePath
+	}
+	if c, ok := compressions[opts.Compress]; ok && !strings.HasSuffix(path, c.ext) {
+		path += c.ext
+	}
+	return path
+}
+
+// openSink opens the output file, or the first part of a split output,
+// continuing from offset when it is positive.
+func openSink(opts Options, compress string, f format, offset int64) (sink, error) {
+	if opts.SplitLines > 0 || opts.SplitMegabytes > 0 {
+		// The manifest of an earlier run would list parts this run is
+		// about to replace.
+		if err := os.Remove(ManifestPath(opts)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return newSplitSink(opts, compress, f, offset), nil
+	}
+	return newFileSink(OutputPath(opts), compress, f, offset)
+}
+
+// sink receives the final words of a run, one at a time.
+type sink interface {
+	Write(c candidate) error
+	// Skip accounts for a word a resumed run already wrote, without
+	// writing it again.
+	Skip(c candidate)
+	// Checkpoint flushes everything written so far and returns the size of
+	// the output a resumed run can continue from.
+	Checkpoint() (int64, error)
+	Close() error
+}
+
+// completer is a sink with more to write once a run completed, after it
+// was closed.
+type completer interface {
+	complete() error
+}
+
+// closeCompleted closes the sink of a completed run.
+func closeCompleted(out sink) error {
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if c, ok := out.(completer); ok {
+		return c.complete()
+	}
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// fileSink writes words to a file, optionally compressed. Every checkpoint
+// ends the current compressed stream and starts a new one, which gzip, zstd
+// and xz all read back as a single file, so a resumed run can append to it.
+type fileSink struct {
+	file      *os.File
+	buf       *bufio.Writer
+	counter   *countingWriter
+	w         io.Writer
+	enc       io.WriteCloser
+	newWriter func(io.Writer) (io.WriteCloser, error)
+	format    format
+}
+
+// newFileSink creates the output file, or when offset is positive opens the
+// existing one, truncates it to offset and appends to it.
+func newFileSink(filepath, compress string, f format, offset int64) (*fileSink, error) {
+	var file *os.File
+	var err error
+	if offset <= 0 {
+		file, err = os.Create(filepath)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		file, err = os.OpenFile(filepath, os.O_WRONLY, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.Truncate(offset); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	buf := bufio.NewWriter(file)
+	s := &fileSink{
+		file:    file,
+		buf:     buf,
+		counter: &countingWriter{w: buf, n: offset},
+		format:  f,
+	}
+	s.w = s.counter
+	if c, ok := compressions[compress]; ok {
+		s.newWriter = c.newWriter
+		if err := s.startStream(); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if offset <= 0 && f.header != "" {
+		if err := s.writeLine(f.header); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *fileSink) startStream() error {
+	enc, err := s.newWriter(s.counter)
+	if err != nil {
+		return err
+	}
+	s.enc = enc
+	s.w = enc
+	return nil
+}
+
+func (s *fileSink) endStream() error {
+	if s.enc == nil {
+		return nil
+	}
+	err := s.enc.Close()
+	s.enc = nil
+	return err
+}
+
+func (s *fileSink) Skip(c candidate) {}
+
+func (s *fileSink) Write(c candidate) error {
+	return s.writeLine(s.format.line(c))
+}
+
+func (s *fileSink) writeLine(line string) error {
+	_, err := io.WriteString(s.w, line+"\n")
+	return err
+}
+
+func (s *fileSink) Checkpoint() (int64, error) {
+	if err := s.endStream(); err != nil {
+		return 0, err
+	}
+	if err := s.buf.Flush(); err != nil {
+		return 0, err
+	}
+	// Some encoders write their header right away, so take the offset
+	// before starting the next stream.
+	offset := s.counter.n
+	if s.newWriter != nil {
+		if err := s.startStream(); err != nil {
+			return 0, err
+		}
+	}
+	return offset, nil
+}
+
+func (s *fileSink) Close() error {
+	if err := s.endStream(); err != nil {
+		s.file.Close()
+		return err
+	}
+	if err := s.buf.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// writerSink writes words to an io.Writer in a format, without
+// checkpoints.
+type writerSink struct {
+	w      *bufio.Writer
+	format format
+}
+
+func newWriterSink(w io.Writer, f format) *writerSink {
+	s := &writerSink{w: bufio.NewWriter(w), format: f}
+	if f.header != "" {
+		s.w.WriteString(f.header + "\n")
+	}
+	return s
+}
+
+func (s *writerSink) Skip(c candidate) {}
+
+func (s *writerSink) Write(c candidate) error {
+	_, err := s.w.WriteString(s.format.line(c) + "\n")
+	return err
+}
+
+func (s *writerSink) Checkpoint() (int64, error) {
+	return 0, s.w.Flush()
+}
+
+func (s *writerSink) Close() error {
+	return s.w.Flush()
+}
+
+// funcSink passes every password to a function.
+type funcSink func(password string) error
+
+func (fn funcSink) Write(c candidate) error {
+	return fn(c.Word)
+}
+
+func (fn funcSink) Skip(c candidate) {}
+
+func (fn funcSink) Checkpoint() (int64, error) {
+	return 0, nil
+}
+
+func (fn funcSink) Close() error {
+	return nil
+}
+
+// candidateSink passes every word with its provenance to a function.
+type candidateSink func(Candidate) error
+
+func (fn candidateSink) Write(c candidate) error {
+	return fn(c.export())
+}
+
+func (fn candidateSink) Skip(c candidate) {}
+
+func (fn candidateSink) Checkpoint() (int64, error) {
+	return 0, nil
+}
+
+func (fn candidateSink) Close() error {
+	return nil
+}
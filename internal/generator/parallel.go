@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"context"
+	"runtime"
+)
+
+// job produces the candidates of one partition of the generation, usually
+// everything derived from a single base word.
+type job func() []candidate
+
+// runJobs runs jobs on a pool of workers and passes their results to emit in
+// job order, so the output does not depend on the number of workers. At most
+// twice the number of workers results are held in memory at once. It stops
+// with the context error once ctx is done.
+func runJobs(ctx context.Context, jobs []job, workers int, emit func([]candidate) error) error {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]chan []candidate, len(jobs))
+	for i := range results {
+		results[i] = make(chan []candidate, 1)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	slots := make(chan struct{}, workers*2)
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := range jobs {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case indexes <- i:
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range indexes {
+				results[i] <- jobs[i]()
+			}
+		}()
+	}
+
+	for i := range jobs {
+		var candidates []candidate
+		select {
+		case candidates = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-slots
+		if err := emit(candidates); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// progressInterval is the least time between two progress reports, except
+// for the last one.
+const progressInterval = 100 * time.Millisecond
+
+// Progress is a snapshot of a running generation.
+type Progress struct {
+	// Produced is the number of candidates enumerated so far, before
+	// filtering and deduplication.
+	Produced int64
+	// Dropped is the number of candidates removed by the length, shard and
+	// strength filters.
+	Dropped int64
+	// Written is the number of unique passwords written to the output.
+	Written int64
+	// JobsDone out of Jobs partitions of the generation are finished.
+	JobsDone int
+	Jobs     int
+	Elapsed  time.Duration
+	// Done is set on the last report, once everything was written.
+	Done bool
+}
+
+// Fraction returns the share of the generation done, from 0 to 1.
+func (p Progress) Fraction() float64 {
+	if p.Done || p.Jobs == 0 {
+		return 1
+	}
+	return float64(p.JobsDone) / float64(p.Jobs)
+}
+
+// Rate returns the number of candidates produced per second.
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Produced) / p.Elapsed.Seconds()
+}
+
+// ETA estimates the time left from the share of jobs done, or returns 0
+// when nothing is done yet.
+func (p Progress) ETA() time.Duration {
+	if p.Done || p.JobsDone == 0 {
+		return 0
+	}
+	left := p.Jobs - p.JobsDone
+	return time.Duration(float64(p.Elapsed) / float64(p.JobsDone) * float64(left))
+}
+
+// progressTracker counts what a generation does and reports it. A nil
+// tracker counts nothing.
+type progressTracker struct {
+	report   func(Progress)
+	start    time.Time
+	last     time.Time
+	jobs     int
+	jobsDone int
+	produced atomic.Int64
+	dropped  atomic.Int64
+	written  atomic.Int64
+}
+
+func newProgressTracker(report func(Progress)) *progressTracker {
+	if report == nil {
+		return nil
+	}
+	return &progressTracker{report: report, start: time.Now()}
+}
+
+// expanded counts the candidates of a job, before and after filtering. It
+// is safe for concurrent use.
+func (t *progressTracker) expanded(produced, kept int) {
+	if t == nil {
+		return
+	}
+	t.produced.Add(int64(produced))
+	t.dropped.Add(int64(produced - kept))
+}
+
+func (t *progressTracker) wrote() {
+	if t != nil {
+		t.written.Add(1)
+	}
+}
+
+// jobDone counts a finished job and reports progress unless it did so
+// recently. Only the goroutine merging job results calls it, so reports are
+// never concurrent.
+func (t *progressTracker) jobDone() {
+	if t == nil {
+		return
+	}
+	t.jobsDone++
+	if now := time.Now(); now.Sub(t.last) >= progressInterval {
+		t.last = now
+		t.send(false)
+	}
+}
+
+func (t *progressTracker) finish() {
+	if t != nil {
+		t.send(true)
+	}
+}
+
+func (t *progressTracker) send(done bool) {
+	t.report(Progress{
+		Produced: t.produced.Load(),
+		Dropped:  t.dropped.Load(),
+		Written:  t.written.Load(),
+		JobsDone: t.jobsDone,
+		Jobs:     t.jobs,
+		Elapsed:  time.Since(t.start),
+		Done:     done,
+	})
+}
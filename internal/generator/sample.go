@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+)
+
+const (
+	// sampleJobs is the most jobs Sample runs to pick random passwords and
+	// estimate the total from.
+	sampleJobs = 16
+	// sampleJobWords is the most base candidates a sampled job expands.
+	sampleJobWords = 2000
+)
+
+// Preview is a sample of the wordlist of a profile.
+type Preview struct {
+	// First are the first passwords in generation order, before ranking.
+	First []string
+	// Random are passwords picked at random from the rest of the wordlist.
+	Random []string
+	// Total is the number of passwords in the wordlist when Exact is set.
+	// Otherwise it is extrapolated from the sampled jobs and counts a
+	// password once for every job making it, an estimate that may be off
+	// either way.
+	Total int64
+	Exact bool
+}
+
+var errSampled = errors.New("sampled")
+
+// Sample returns up to n of the first passwords of opts, up to n random
+// others and the size of the wordlist, without generating all of it: the
+// random passwords and the total come from a fixed random subset of the
+// jobs, each cut down to its first base candidates, so the same options
+// give the same preview. The jobs combining an input word and the template
+// and Markov jobs make very different numbers of passwords, so both are
+// sampled and extrapolated on their own. Ranking, sharding and
+// deduplication options are ignored.
+func Sample(ctx context.Context, opts Options, n int) (Preview, error) {
+	g, err := prepare(opts)
+	if err != nil {
+		return Preview{}, err
+	}
+	keep := func(word string) bool {
+		return inLengthRange(word, g.minLength, g.maxLength)
+	}
+	jobs := buildJobs(opts, g, keep)
+
+	var p Preview
+	first := make(map[string]struct{})
+	err = runJobs(ctx, jobs, opts.Workers, func(batch []candidate) error {
+		for _, c := range batch {
+			if len(p.First) == n {
+				return errSampled
+			}
+			if _, ok := first[c.Word]; !ok {
+				first[c.Word] = struct{}{}
+				p.First = append(p.First, c.Word)
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errSampled {
+		return Preview{}, err
+	}
+
+	// The jobs combining an input word come first, one per word, and are
+	// the ones cut down: scale what they make by the share of their
+	// combinations made.
+	g.jobLimit = sampleJobWords
+	limited := buildJobs(opts, g, keep)
+	combinations := 1 + jobCombinations(opts, len(g.inputs))
+	scale := func(job int) float64 {
+		if job < len(g.inputs) && combinations > sampleJobWords {
+			return float64(combinations) / sampleJobWords
+		}
+		return 1
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(len(limited)), uint64(n)))
+	combining := len(g.inputs)
+	picks := pickJobs(rng, 0, combining, sampleJobs-min(len(limited)-combining, sampleJobs/2))
+	combined := len(picks)
+	picks = append(picks, pickJobs(rng, combining, len(limited), sampleJobs-combined)...)
+	sampled := make([]job, len(picks))
+	for i, pick := range picks {
+		sampled[i] = limited[pick]
+	}
+	// share is the number of jobs of each class over the number sampled.
+	share := [2]float64{1, 1}
+	if combined > 0 {
+		share[0] = float64(combining) / float64(combined)
+	}
+	if others := len(picks) - combined; others > 0 {
+		share[1] = float64(len(limited)-combining) / float64(others)
+	}
+
+	seen := make(map[string]struct{})
+	var (
+		picked   int
+		batches  int
+		estimate float64
+		cut      bool
+	)
+	err = runJobs(ctx, sampled, opts.Workers, func(batch []candidate) error {
+		job := picks[batches]
+		factor := scale(job)
+		class := 0
+		if job >= combining {
+			class = 1
+		}
+		estimate += float64(len(batch)) * factor * share[class]
+		cut = cut || factor > 1
+		batches++
+		for _, c := range batch {
+			if _, ok := seen[c.Word]; ok {
+				continue
+			}
+			seen[c.Word] = struct{}{}
+			if _, ok := first[c.Word]; ok {
+				continue
+			}
+			// Reservoir sampling keeps every other password with the
+			// same chance.
+			picked++
+			if len(p.Random) < n {
+				p.Random = append(p.Random, c.Word)
+			} else if i := rng.IntN(picked); i < n {
+				p.Random[i] = c.Word
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Preview{}, err
+	}
+
+	p.Exact = len(sampled) == len(limited) && !cut
+	p.Total = int64(len(seen))
+	if !p.Exact {
+		p.Total = int64(estimate)
+	}
+	return p, nil
+}
+
+// pickJobs returns up to n of the job indexes from start to end, picked at
+// random, in order.
+func pickJobs(rng *rand.Rand, start, end, n int) []int {
+	if end-start <= n {
+		picks := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			picks = append(picks, i)
+		}
+		return picks
+	}
+	picks := rng.Perm(end - start)[:n]
+	for i := range picks {
+		picks[i] += start
+	}
+	slices.Sort(picks)
+	return picks
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// inShard reports whether word belongs to shard index (1 based) out of count.
+// Words are assigned by hash, so the shards never overlap and together cover
+// the whole list. Each shard still enumerates every base word, since the
+// variants of one base word land in different shards, but only dedups and
+// writes its own slice.
+func inShard(word string, index, count int) bool {
+	if count <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(word))
+	return int(h.Sum32()%uint32(count)) == index-1
+}
+
+func validateShard(index, count int) error {
+	if count == 0 && index == 0 {
+		return nil
+	}
+	if count < 1 || index < 1 || index > count {
+		return fmt.Errorf("invalid shard %d/%d: shard must be between 1 and the shard count", index, count)
+	}
+	return nil
+}
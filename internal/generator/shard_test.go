@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestShardUnion(t *testing.T) {
+	tests := []struct {
+		name   string
+		shards int
+		opts   Options
+	}{
+		{name: "one shard", shards: 1},
+		{name: "two shards", shards: 2},
+		{name: "seven shards", shards: 7, opts: Options{EnableLeet: true, EnableCapitalize: true}},
+		{name: "templates", shards: 3, opts: Options{EnableTemplates: true}},
+		{name: "sort dedup", shards: 4, opts: Options{Dedup: DedupSort}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.InputFirstName = []string{"John"}
+			opts.InputLastName = []string{"Doe"}
+			opts.InputBirthday = []string{"03", "07", "1991"}
+			opts.InputRelatedWords = []string{"rex", "blue"}
+			opts.TempDir = t.TempDir()
+			want := collect(t, opts)
+
+			var union []string
+			seen := make(map[string]int)
+			for index := 1; index <= tt.shards; index++ {
+				opts.ShardIndex, opts.ShardCount = index, tt.shards
+				shard := collect(t, opts)
+				for _, password := range shard {
+					if other, ok := seen[password]; ok {
+						t.Errorf("%s is in shards %d and %d", password, other, index)
+					}
+					seen[password] = index
+				}
+				union = append(union, shard...)
+			}
+
+			slices.Sort(want)
+			slices.Sort(union)
+			if !slices.Equal(union, want) {
+				t.Errorf("the shards hold %d passwords, want the %d of the whole wordlist", len(union), len(want))
+			}
+		})
+	}
+}
+
+func TestValidateShard(t *testing.T) {
+	tests := []struct {
+		index, count int
+		valid        bool
+	}{
+		{0, 0, true},
+		{1, 1, true},
+		{3, 4, true},
+		{4, 4, true},
+		{0, 4, false},
+		{5, 4, false},
+		{1, 0, false},
+		{-1, 2, false},
+	}
+	for _, tt := range tests {
+		if err := validateShard(tt.index, tt.count); (err == nil) != tt.valid {
+			t.Errorf("validateShard(%d, %d) = %v, want valid %v", tt.index, tt.count, err, tt.valid)
+		}
+	}
+}
+
+func collect(t *testing.T, opts Options) []string {
+	t.Helper()
+	var passwords []string
+	if err := Each(context.Background(), opts, func(password string) error {
+		passwords = append(passwords, password)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return passwords
+}
@@ -0,0 +1,344 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Source produces base tokens, the words candidates are combined from.
+type Source interface {
+	// Name identifies the source in flags and profile files.
+	Name() string
+	Description() string
+	// Tokens returns the tokens for the arguments the source was selected
+	// with, e.g. the paths of the file source.
+	Tokens(args []string) ([]string, error)
+}
+
+// SourceSpec selects a source and its arguments.
+type SourceSpec struct {
+	Name string
+	Args []string
+}
+
+// ParseSourceSpec parses a source as given on the command line or in a
+// profile file: its name, optionally followed by a colon and comma separated
+// arguments, e.g. "file:pets.txt" or "numbers:1990-2025".
+func ParseSourceSpec(s string) (SourceSpec, error) {
+	name, args := splitSpec(s)
+	spec := SourceSpec{Name: name, Args: args}
+	if _, ok := LookupSource(name); !ok {
+		return SourceSpec{}, fmt.Errorf("unknown source %q (see the sources list command)", name)
+	}
+	return spec, nil
+}
+
+// splitSpec splits a source or transform given as "name:arg,arg" into its
+// name and non-empty arguments.
+func splitSpec(s string) (string, []string) {
+	name, list, hasArgs := strings.Cut(strings.TrimSpace(s), ":")
+	var args []string
+	if hasArgs {
+		for _, arg := range strings.Split(list, ",") {
+			if arg = strings.TrimSpace(arg); arg != "" {
+				args = append(args, arg)
+			}
+		}
+	}
+	return name, args
+}
+
+func (s SourceSpec) String() string {
+	if len(s.Args) == 0 {
+		return s.Name
+	}
+	return s.Name + ":" + strings.Join(s.Args, ",")
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = make(map[string]Source)
+)
+
+// RegisterSource makes a source available by its name. It panics if the
+// name is empty or already taken.
+func RegisterSource(s Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	name := s.Name()
+	if name == "" {
+		panic("generator: source without a name")
+	}
+	if _, ok := sources[name]; ok {
+		panic(fmt.Sprintf("generator: source %q registered twice", name))
+	}
+	sources[name] = s
+}
+
+// LookupSource returns the source registered under name.
+func LookupSource(name string) (Source, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	s, ok := sources[name]
+	return s, ok
+}
+
+// Sources returns every registered source, sorted by name.
+func Sources() []Source {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	list := make([]Source, 0, len(sources))
+	for _, s := range sources {
+		list = append(list, s)
+	}
+	slices.SortFunc(list, func(a, b Source) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return list
+}
+
+// sourceSpecs returns the sources to collect tokens from, in order: the
+// profile inputs of opts, then opts.Sources.
+func sourceSpecs(opts Options) []SourceSpec {
+	var specs []SourceSpec
+	for _, spec := range []SourceSpec{
+		{Name: firstNameSource.name, Args: opts.InputFirstName},
+		{Name: lastNameSource.name, Args: opts.InputLastName},
+		{Name: wordsSource.name, Args: opts.InputRelatedWords},
+		{Name: birthdaySource{}.Name(), Args: opts.InputBirthday},
+	} {
+		if len(spec.Args) > 0 {
+			specs = append(specs, spec)
+		}
+	}
+	return append(specs, opts.Sources...)
+}
+
+func validateSources(opts Options) error {
+	for _, spec := range opts.Sources {
+		if _, ok := LookupSource(spec.Name); !ok {
+			return fmt.Errorf("unknown source %q (see the sources list command)", spec.Name)
+		}
+	}
+	return nil
+}
+
+// collectTokens returns the tokens of every source of opts in order, and
+// the name of the source each token of opts.Sources came from first.
+func collectTokens(opts Options) ([]string, map[string]string, error) {
+	tokens := []string{}
+	origins := make(map[string]string)
+	specs := sourceSpecs(opts)
+	builtin := len(specs) - len(opts.Sources)
+	for i, spec := range specs {
+		s, ok := LookupSource(spec.Name)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown source %q (see the sources list command)", spec.Name)
+		}
+		list, err := s.Tokens(spec.Args)
+		if err != nil {
+			return nil, nil, fmt.Errorf("source %s: %w", spec.Name, err)
+		}
+		tokens = append(tokens, list...)
+		if i < builtin {
+			continue
+		}
+		for _, token := range list {
+			if _, ok := origins[token]; !ok {
+				origins[token] = spec.Name
+			}
+		}
+	}
+	return tokens, origins, nil
+}
+
+// wordSource yields every argument as is and capitalized.
+type wordSource struct {
+	name        string
+	description string
+}
+
+func (s wordSource) Name() string        { return s.name }
+func (s wordSource) Description() string { return s.description }
+
+func (s wordSource) Tokens(args []string) ([]string, error) {
+	return withCapitalized(args), nil
+}
+
+func withCapitalized(words []string) []string {
+	var tokens []string
+	for _, w := range words {
+		tokens = append(tokens, w)
+		if capW := capitalize(w); capW != w {
+			tokens = append(tokens, capW)
+		}
+	}
+	return tokens
+}
+
+// birthdaySource yields the birthday parts joined and on their own.
+type birthdaySource struct{}
+
+func (birthdaySource) Name() string { return "birthday" }
+func (birthdaySource) Description() string {
+	return "Birthday parts, joined and on their own: 03,07,1991"
+}
+
+func (birthdaySource) Tokens(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	return append([]string{strings.Join(args, "")}, args...), nil
+}
+
+// fileSource yields the words of wordlist files, as is, or the passwords of
+// JSON Lines and CSV wordlists.
+type fileSource struct{}
+
+func (fileSource) Name() string { return "file" }
+func (fileSource) Description() string {
+	return "Words of text files, one per line (may be compressed): file:pets.txt"
+}
+
+func (fileSource) Tokens(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no file given")
+	}
+	var tokens []string
+	for _, path := range args {
+		r, err := OpenWordlist(path)
+		if err != nil {
+			return nil, err
+		}
+		err = ScanPasswords(r, WordlistFormat(path), func(word, _ string) error {
+			if word = strings.TrimSpace(word); word != "" {
+				tokens = append(tokens, word)
+			}
+			return nil
+		})
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return tokens, nil
+}
+
+// phoneSource yields phone numbers without formatting, and their last
+// digits.
+type phoneSource struct{}
+
+func (phoneSource) Name() string { return "phone" }
+func (phoneSource) Description() string {
+	return "Phone numbers, all digits and the last 4: phone:+1 555 0100"
+}
+
+func (phoneSource) Tokens(args []string) ([]string, error) {
+	var tokens []string
+	for _, phone := range args {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, phone)
+		if digits == "" {
+			return nil, fmt.Errorf("no digits in phone number %q", phone)
+		}
+		tokens = append(tokens, digits)
+		if len(digits) > 4 {
+			tokens = append(tokens, digits[len(digits)-4:])
+		}
+	}
+	return tokens, nil
+}
+
+var keyboardWalks = []string{
+	"qwerty", "qwertyuiop", "asdf", "asdfgh", "zxcvbn", "qazwsx",
+	"1qaz", "1qaz2wsx", "1q2w3e", "1q2w3e4r", "123qwe", "qweasd", "azerty",
+}
+
+// keyboardSource yields common keyboard walks.
+type keyboardSource struct{}
+
+func (keyboardSource) Name() string { return "keyboard" }
+func (keyboardSource) Description() string {
+	return "Common keyboard walks: qwerty, asdf, 1qaz2wsx, ..."
+}
+
+func (keyboardSource) Tokens(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("takes no arguments")
+	}
+	return slices.Clone(keyboardWalks), nil
+}
+
+// maxNumberRange is the most numbers a single range of the numbers source
+// may hold, as every token is combined with every other.
+const maxNumberRange = 1000
+
+// numberSource yields the numbers of ranges, or common numbers without
+// arguments.
+type numberSource struct{}
+
+func (numberSource) Name() string { return "numbers" }
+func (numberSource) Description() string {
+	return "Numbers of ranges, zero padded like the start (default 1, 12, 123, ...): numbers:00-99,1990-2025"
+}
+
+func (numberSource) Tokens(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return slices.Clone(templateNumbers), nil
+	}
+	var tokens []string
+	for _, arg := range args {
+		from, to, isRange := strings.Cut(arg, "-")
+		if !isRange {
+			to = from
+		}
+		start, err := strconv.Atoi(from)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid number range %q", arg)
+		}
+		end, err := strconv.Atoi(to)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid number range %q", arg)
+		}
+		if end-start >= maxNumberRange {
+			return nil, fmt.Errorf("number range %q holds more than %d numbers", arg, maxNumberRange)
+		}
+		for n := start; n <= end; n++ {
+			tokens = append(tokens, fmt.Sprintf("%0*d", len(from), n))
+		}
+	}
+	return tokens, nil
+}
+
+var (
+	firstNameSource = wordSource{
+		name:        "firstname",
+		description: "First names, as is and capitalized",
+	}
+	lastNameSource = wordSource{
+		name:        "lastname",
+		description: "Last names, as is and capitalized",
+	}
+	wordsSource = wordSource{
+		name:        "words",
+		description: "Related words, as is and capitalized: words:rex,paris",
+	}
+)
+
+func init() {
+	RegisterSource(firstNameSource)
+	RegisterSource(lastNameSource)
+	RegisterSource(wordsSource)
+	RegisterSource(birthdaySource{})
+	RegisterSource(fileSource{})
+	RegisterSource(keyboardSource{})
+	RegisterSource(phoneSource{})
+	RegisterSource(numberSource{})
+}
@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// partPath returns the path of part n of a split output, numbered before the
+// file extension: wordlist.txt becomes wordlist.001.txt and wordlist.txt.gz
+// becomes wordlist.001.txt.gz.
+func partPath(path, compress string, n int) string {
+	suffix := ""
+	if c, ok := compressions[compress]; ok && strings.HasSuffix(path, c.ext) {
+		path, suffix = strings.TrimSuffix(path, c.ext), c.ext
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%03d%s%s", strings.TrimSuffix(path, ext), n, ext, suffix)
+}
+
+// ManifestPath returns the path of the manifest listing the parts of a split
+// output.
+func ManifestPath(opts Options) string {
+	return OutputPath(opts) + ".manifest"
+}
+
+// splitSink writes words to numbered part files, starting a new part once
+// the current one reaches maxLines lines or maxBytes bytes of uncompressed
+// text, not counting headers. Once the run completes it writes a manifest
+// with every part and its line count.
+type splitSink struct {
+	path         string
+	compress     string
+	format       format
+	manifestPath string
+	maxLines     int64
+	maxBytes     int64
+
+	part   int
+	lines  int64
+	bytes  int64
+	parts  []int64
+	offset int64
+
+	current *fileSink
+}
+
+func newSplitSink(opts Options, compress string, f format, offset int64) *splitSink {
+	return &splitSink{
+		path:         OutputPath(opts),
+		compress:     compress,
+		format:       f,
+		manifestPath: ManifestPath(opts),
+		maxLines:     int64(opts.SplitLines),
+		maxBytes:     int64(opts.SplitMegabytes) * 1024 * 1024,
+		part:         1,
+		offset:       offset,
+	}
+}
+
+// advance counts line towards the current part and reports whether it
+// starts a new part.
+func (s *splitSink) advance(line string) bool {
+	size := int64(len(line) + 1)
+	full := (s.maxLines > 0 && s.lines >= s.maxLines) || (s.maxBytes > 0 && s.bytes+size > s.maxBytes)
+	roll := s.lines > 0 && full
+	if roll {
+		s.parts = append(s.parts, s.lines)
+		s.part++
+		s.lines, s.bytes = 0, 0
+	}
+	s.lines++
+	s.bytes += size
+	return roll
+}
+
+// open opens the current part, continuing from the resume offset if there
+// is one.
+func (s *splitSink) open() error {
+	current, err := newFileSink(partPath(s.path, s.compress, s.part), s.compress, s.format, s.offset)
+	if err != nil {
+		return err
+	}
+	s.current = current
+	s.offset = 0
+	return nil
+}
+
+func (s *splitSink) Skip(c candidate) {
+	s.advance(s.format.line(c))
+}
+
+func (s *splitSink) Write(c candidate) error {
+	line := s.format.line(c)
+	if s.current == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.advance(line) {
+		if err := s.current.Close(); err != nil {
+			return err
+		}
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	return s.current.writeLine(line)
+}
+
+func (s *splitSink) Checkpoint() (int64, error) {
+	if s.current == nil {
+		return s.offset, nil
+	}
+	return s.current.Checkpoint()
+}
+
+func (s *splitSink) Close() error {
+	if s.current == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	return s.current.Close()
+}
+
+// complete writes the manifest, so that parts are only listed once they
+// are all there.
+func (s *splitSink) complete() error {
+	file, err := os.Create(s.manifestPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	for i, lines := range append(s.parts, s.lines) {
+		fmt.Fprintf(w, "%s\t%d\n", filepath.Base(partPath(s.path, s.compress, i+1)), lines)
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
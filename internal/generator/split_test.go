@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPartPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		compress string
+		n        int
+		want     string
+	}{
+		{"wordlist.txt", CompressNone, 1, "wordlist.001.txt"},
+		{"wordlist.txt.gz", CompressGzip, 2, "wordlist.002.txt.gz"},
+		{"out/list.csv.zst", CompressZstd, 12, "out/list.012.csv.zst"},
+		{"wordlist", CompressNone, 3, "wordlist.003"},
+		{"wordlist.gz", CompressNone, 1, "wordlist.001.gz"},
+	}
+	for _, tt := range tests {
+		if got := partPath(tt.path, tt.compress, tt.n); got != tt.want {
+			t.Errorf("partPath(%q, %q, %d) = %q, want %q", tt.path, tt.compress, tt.n, got, tt.want)
+		}
+	}
+}
+
+// readParts returns the passwords of every part listed in the manifest of
+// opts, checking that the manifest counts them right.
+func readParts(t *testing.T, opts Options) [][]string {
+	t.Helper()
+	manifest, err := os.ReadFile(ManifestPath(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts [][]string
+	for _, line := range strings.Split(strings.TrimSpace(string(manifest)), "\n") {
+		name, count, _ := strings.Cut(line, "\t")
+		path := filepath.Join(filepath.Dir(OutputPath(opts)), name)
+		r, err := OpenWordlist(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var passwords []string
+		err = ScanPasswords(r, WordlistFormat(path), func(password, _ string) error {
+			passwords = append(passwords, password)
+			return nil
+		})
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := strconv.Itoa(len(passwords)); count != want {
+			t.Errorf("manifest lists %s with %s lines, it has %s", name, count, want)
+		}
+		parts = append(parts, passwords)
+	}
+	return parts
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		lines  int
+	}{
+		{name: "plain", output: "out.txt", lines: 300},
+		{name: "gzip", output: "out.txt.gz", lines: 300},
+		{name: "xz", output: "out.txt.xz", lines: 1000},
+		{name: "csv", output: "out.csv", lines: 500},
+		{name: "jsonl zstd", output: "out.jsonl.zst", lines: 700},
+		{name: "one part", output: "out.txt", lines: 1 << 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{
+				InputFirstName:    []string{"John"},
+				InputLastName:     []string{"Doe"},
+				InputBirthday:     []string{"03", "07", "1991"},
+				InputRelatedWords: []string{"rex", "blue"},
+				EnableLeet:        true,
+				EnableCapitalize:  true,
+			}
+			want := collect(t, opts)
+
+			opts.OutputFilePath = filepath.Join(t.TempDir(), tt.output)
+			opts.SplitLines = tt.lines
+			if err := Run(context.Background(), opts, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			parts := readParts(t, opts)
+			if wantParts := (len(want) + tt.lines - 1) / tt.lines; len(parts) != wantParts {
+				t.Errorf("got %d parts, want %d", len(parts), wantParts)
+			}
+			for i, part := range parts {
+				if i < len(parts)-1 && len(part) != tt.lines {
+					t.Errorf("part %d has %d lines, want %d", i+1, len(part), tt.lines)
+				}
+			}
+			if got := slices.Concat(parts...); !slices.Equal(got, want) {
+				t.Errorf("parts hold %d passwords, want the %d of the unsplit wordlist", len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestSplitBytes(t *testing.T) {
+	const maxBytes = 1000
+	opts := Options{OutputFilePath: filepath.Join(t.TempDir(), "out.csv"), SplitMegabytes: 1}
+	s := newSplitSink(opts, CompressNone, formats[FormatCSV], 0)
+	s.maxBytes = maxBytes
+
+	var want []string
+	for i := range 500 {
+		password := "password" + strconv.Itoa(i)
+		want = append(want, password)
+		if err := s.Write(newCandidate(password, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := closeCompleted(s); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := readParts(t, opts)
+	if len(parts) < 2 {
+		t.Fatalf("got %d parts, want the output split", len(parts))
+	}
+	for i := range parts {
+		info, err := os.Stat(partPath(OutputPath(opts), CompressNone, i+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The header is not counted.
+		if size := info.Size() - int64(len(formats[FormatCSV].header)+1); size > maxBytes {
+			t.Errorf("part %d has %d bytes, want at most %d", i+1, size, maxBytes)
+		}
+	}
+	if got := slices.Concat(parts...); !slices.Equal(got, want) {
+		t.Errorf("parts hold %d passwords, want %d", len(got), len(want))
+	}
+}
+
+func TestSplitManifestOnlyOnCompletion(t *testing.T) {
+	opts := Options{OutputFilePath: filepath.Join(t.TempDir(), "out.txt"), SplitLines: 2}
+	writeFile(t, ManifestPath(opts), []byte("stale.001.txt\t2\n"))
+
+	s, err := openSink(opts, CompressNone, formats[FormatPlain], 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(ManifestPath(opts)); !os.IsNotExist(err) {
+		t.Errorf("stale manifest kept when a new run starts: %v", err)
+	}
+	for _, password := range []string{"a", "b", "c"} {
+		if err := s.Write(newCandidate(password, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(ManifestPath(opts)); !os.IsNotExist(err) {
+		t.Errorf("manifest written before the run completed: %v", err)
+	}
+
+	if err := s.(completer).complete(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readParts(t, opts); !slices.EqualFunc(got, [][]string{{"a", "b"}, {"c"}}, slices.Equal) {
+		t.Errorf("got parts %q, want [[a b] [c]]", got)
+	}
+}
@@ -0,0 +1,342 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// builtinTemplates are common password structures filled from the profile
+// when templates are enabled.
+var builtinTemplates = []string{
+	"{First}{yyyy}",
+	"{first}{yyyy}",
+	"{First}{yy}",
+	"{first}{yy}",
+	"{First}{Sym}",
+	"{First}{yyyy}{Sym}",
+	"{First}{Sym}{yyyy}",
+	"{first}{last}{yy}",
+	"{First}{Last}{yyyy}",
+	"{First}{Last}{Sym}",
+	"{first}.{last}",
+	"{first}_{last}",
+	"{first:initial}{last}",
+	"{first:initial}{last}{yyyy}",
+	"{last:upper}{dd}{mm}",
+	"{Last}{dd}{mm}{yy}",
+	"{first}{dd}{mm}",
+	"{first}{dd}{mm}{yy}",
+	"{Word}{yyyy}",
+	"{word}{yy}",
+	"{Word}{Sym}",
+	"{word}{num}",
+	"{First}{num}",
+	"{First}{num}{Sym}",
+}
+
+var (
+	templateSymbols = []string{"!", "@", "#", "$", ".", "_", "*", "?"}
+	templateNumbers = []string{"1", "12", "123", "1234", "01", "007", "69", "99"}
+	templateDigits  = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+)
+
+type templateSegment struct {
+	literal     string
+	placeholder string
+	modifier    string
+}
+
+type template struct {
+	source   string
+	segments []templateSegment
+}
+
+func parseTemplate(source string) (template, error) {
+	t := template{source: source}
+	rest := source
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				return t, fmt.Errorf("template %q: unexpected }", source)
+			}
+			t.segments = append(t.segments, templateSegment{literal: rest})
+			break
+		}
+		if open > 0 {
+			if strings.IndexByte(rest[:open], '}') >= 0 {
+				return t, fmt.Errorf("template %q: unexpected }", source)
+			}
+			t.segments = append(t.segments, templateSegment{literal: rest[:open]})
+		}
+		closing := strings.IndexByte(rest[open:], '}')
+		if closing < 0 {
+			return t, fmt.Errorf("template %q: missing }", source)
+		}
+		field := rest[open+1 : open+closing]
+		rest = rest[open+closing+1:]
+
+		placeholder, modifier, _ := strings.Cut(field, ":")
+		if placeholder == "" {
+			return t, fmt.Errorf("template %q: empty placeholder", source)
+		}
+		if _, ok := templateValues(placeholder, profile{}); !ok {
+			return t, fmt.Errorf("template %q: unknown placeholder {%s}", source, placeholder)
+		}
+		if modifier == "" {
+			modifier = defaultModifier(placeholder)
+		}
+		if _, ok := templateModifiers[strings.ToLower(modifier)]; !ok {
+			return t, fmt.Errorf("template %q: unknown modifier %q", source, modifier)
+		}
+		t.segments = append(t.segments, templateSegment{
+			placeholder: strings.ToLower(placeholder),
+			modifier:    strings.ToLower(modifier),
+		})
+	}
+	// Placeholders filled from the profile are counted once here, and in
+	// full by checkSize once the profile is known.
+	return t, t.checkSize(profile{})
+}
+
+// size returns the most passwords t makes from p. A placeholder p has no
+// values for is counted as one.
+func (t template) size(p profile) int {
+	total := 1
+	for _, segment := range t.segments {
+		if segment.placeholder == "" {
+			continue
+		}
+		values, _ := templateValues(segment.placeholder, p)
+		if len(values) == 0 {
+			continue
+		}
+		total = saturatingMul(total, len(values))
+	}
+	return total
+}
+
+// checkSize fails when t makes more passwords from p than a job may hold,
+// maxJobCombinations.
+func (t template) checkSize(p profile) error {
+	if size := t.size(p); size > maxJobCombinations {
+		return fmt.Errorf("template %q makes %d passwords, more than the %d a template may make", t.source, size, maxJobCombinations)
+	}
+	return nil
+}
+
+// defaultModifier derives the case of a placeholder from how it is written:
+// {First} is capitalized, {FIRST} is upper case and {first} is lower case.
+func defaultModifier(placeholder string) string {
+	switch {
+	case strings.ToUpper(placeholder) == placeholder && len(placeholder) > 1:
+		return "upper"
+	case unicode.IsUpper([]rune(placeholder)[0]):
+		return "cap"
+	default:
+		return "lower"
+	}
+}
+
+var templateModifiers = map[string]func(string) string{
+	"raw":     func(s string) string { return s },
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"cap":     capitalize,
+	"leet":    leetWord,
+	"initial": initial,
+}
+
+func initial(word string) string {
+	for _, char := range word {
+		return string(char)
+	}
+	return ""
+}
+
+// profile holds the personal information templates are filled from.
+type profile struct {
+	firstNames   []string
+	lastNames    []string
+	relatedWords []string
+	day          string
+	month        string
+	year         string
+}
+
+func newProfile(opts Options) profile {
+	p := profile{
+		firstNames:   opts.InputFirstName,
+		lastNames:    opts.InputLastName,
+		relatedWords: opts.InputRelatedWords,
+	}
+	p.day, p.month, p.year = splitBirthday(opts.InputBirthday)
+	return p
+}
+
+// splitBirthday finds the year, month and day in a birthday split on /.
+// The year is the four digit part, the rest are read as day then month
+// unless the year comes first (YYYY/MM/DD).
+func splitBirthday(parts []string) (day, month, year string) {
+	var rest []string
+	yearFirst := false
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if year == "" && len(part) == 4 {
+			year = part
+			yearFirst = i == 0
+			continue
+		}
+		rest = append(rest, part)
+	}
+	if year == "" && len(rest) == 3 {
+		year, rest = rest[2], rest[:2]
+	}
+	if yearFirst && len(rest) >= 2 {
+		rest[0], rest[1] = rest[1], rest[0]
+	}
+	if len(rest) > 0 {
+		day = rest[0]
+	}
+	if len(rest) > 1 {
+		month = rest[1]
+	}
+	return day, month, year
+}
+
+func optional(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func shortYear(year string) []string {
+	if len(year) < 2 {
+		return optional(year)
+	}
+	return []string{year[len(year)-2:]}
+}
+
+func templateValues(placeholder string, p profile) ([]string, bool) {
+	switch strings.ToLower(placeholder) {
+	case "first":
+		return p.firstNames, true
+	case "last":
+		return p.lastNames, true
+	case "name":
+		return append(append([]string{}, p.firstNames...), p.lastNames...), true
+	case "word":
+		return p.relatedWords, true
+	case "dd", "day":
+		return optional(p.day), true
+	case "mm", "month":
+		return optional(p.month), true
+	case "yyyy", "year", "year4":
+		return optional(p.year), true
+	case "yy", "year2":
+		return shortYear(p.year), true
+	case "sym":
+		return templateSymbols, true
+	case "num":
+		return templateNumbers, true
+	case "digit":
+		return templateDigits, true
+	}
+	return nil, false
+}
+
+func (t template) expand(p profile) []candidate {
+	result := []candidate{newCandidate("", nil, "template:"+t.source)}
+	for _, segment := range t.segments {
+		if segment.placeholder == "" {
+			for i := range result {
+				result[i].Word += segment.literal
+			}
+			continue
+		}
+		values, _ := templateValues(segment.placeholder, p)
+		modify := templateModifiers[segment.modifier]
+		next := make([]candidate, 0, len(result)*len(values))
+		for _, prefix := range result {
+			for _, value := range values {
+				sources := make([]string, len(prefix.Sources), len(prefix.Sources)+1)
+				copy(sources, prefix.Sources)
+				next = append(next, candidate{
+					Word:       prefix.Word + modify(value),
+					Sources:    append(sources, value),
+					Transforms: prefix.Transforms,
+				})
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+func loadTemplateFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sources []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sources = append(sources, line)
+	}
+	return sources, scanner.Err()
+}
+
+func collectTemplates(opts Options) ([]template, error) {
+	var sources []string
+	if opts.EnableTemplates {
+		sources = append(sources, builtinTemplates...)
+	}
+	if opts.TemplateFilePath != "" {
+		fileSources, err := loadTemplateFile(opts.TemplateFilePath)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, fileSources...)
+	}
+
+	p := newProfile(opts)
+	templates := make([]template, 0, len(sources))
+	for _, source := range sources {
+		t, err := parseTemplate(source)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.checkSize(p); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// Templates returns the templates opts enables, the built-in ones followed
+// by those of the template file, after checking that they parse.
+func Templates(opts Options) ([]string, error) {
+	templates, err := collectTemplates(opts)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]string, len(templates))
+	for i, t := range templates {
+		sources[i] = t.source
+	}
+	return sources, nil
+}
@@ -0,0 +1,309 @@
+package generator
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Transform derives variants of a word, such as its leet speak spelling.
+type Transform interface {
+	// Name identifies the transform in flags, profile files and the
+	// transforms of a candidate.
+	Name() string
+	Description() string
+	// Apply yields the variants of word. Yielding word itself is allowed
+	// and ignored.
+	Apply(word string) iter.Seq[string]
+}
+
+// ConfigurableTransform is a transform that takes arguments, given after
+// its name as in "leet:a=@,s=$".
+type ConfigurableTransform interface {
+	Transform
+	// Configure returns the transform with args in place of its defaults.
+	Configure(args []string) (Transform, error)
+}
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = make(map[string]Transform)
+)
+
+// RegisterTransform makes a transform available by its name. It panics if
+// the name is empty or already taken.
+func RegisterTransform(t Transform) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	name := t.Name()
+	if name == "" {
+		panic("generator: transform without a name")
+	}
+	if _, ok := transforms[name]; ok {
+		panic(fmt.Sprintf("generator: transform %q registered twice", name))
+	}
+	transforms[name] = t
+}
+
+// LookupTransform returns the transform registered under name.
+func LookupTransform(name string) (Transform, bool) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	t, ok := transforms[name]
+	return t, ok
+}
+
+// Transforms returns every registered transform, sorted by name.
+func Transforms() []Transform {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	list := make([]Transform, 0, len(transforms))
+	for _, t := range transforms {
+		list = append(list, t)
+	}
+	slices.SortFunc(list, func(a, b Transform) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return list
+}
+
+// ParseTransform returns the transform given as on the command line or in
+// a profile file: its name, optionally followed by a colon and comma
+// separated arguments for a ConfigurableTransform, e.g. "suffix:1,123,!".
+func ParseTransform(spec string) (Transform, error) {
+	name, args := splitSpec(spec)
+	t, ok := LookupTransform(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown transform %q (see the transforms list command)", name)
+	}
+	if len(args) == 0 {
+		return t, nil
+	}
+	c, ok := t.(ConfigurableTransform)
+	if !ok {
+		return nil, fmt.Errorf("transform %s takes no arguments, got %q", name, spec)
+	}
+	t, err := c.Configure(args)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// transformNames returns the transforms to apply, in order: those of
+// opts.Transforms, then leet and swapcase when enabled with their own
+// options and not listed already.
+func transformNames(opts Options) []string {
+	names := slices.Clone(opts.Transforms)
+	listed := func(name string) bool {
+		return slices.ContainsFunc(names, func(spec string) bool {
+			listedName, _ := splitSpec(spec)
+			return listedName == name
+		})
+	}
+	if opts.EnableLeet && !listed(leetTransform{}.Name()) {
+		names = append(names, leetTransform{}.Name())
+	}
+	if opts.EnableCapitalize && !listed(swapCaseTransform.Name()) {
+		names = append(names, swapCaseTransform.Name())
+	}
+	return names
+}
+
+func resolveTransforms(opts Options) ([]Transform, error) {
+	var list []Transform
+	for _, spec := range transformNames(opts) {
+		t, err := ParseTransform(spec)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// applyTransforms applies every transform in turn to all candidates so
+// far, appending the new variants after them.
+func applyTransforms(candidates []candidate, list []Transform) []candidate {
+	for _, t := range list {
+		var variants []candidate
+		for _, c := range candidates {
+			for variant := range t.Apply(c.Word) {
+				if variant != c.Word {
+					variants = append(variants, c.derive(variant, t.Name()))
+				}
+			}
+		}
+		candidates = removeDuplicateCandidates(append(candidates, variants...))
+	}
+	return candidates
+}
+
+// simpleTransform is a transform that maps every word to a single variant.
+type simpleTransform struct {
+	name        string
+	description string
+	apply       func(string) string
+}
+
+func (t simpleTransform) Name() string        { return t.name }
+func (t simpleTransform) Description() string { return t.description }
+
+func (t simpleTransform) Apply(word string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		yield(t.apply(word))
+	}
+}
+
+var swapCaseTransform = simpleTransform{
+	name:        "swapcase",
+	description: "Swap the case of every letter: John → jOHN",
+	apply:       swapCase,
+}
+
+func init() {
+	RegisterTransform(leetTransform{})
+	RegisterTransform(swapCaseTransform)
+	RegisterTransform(suffixTransform{})
+	RegisterTransform(simpleTransform{
+		name:        "upper",
+		description: "Upper case: john → JOHN",
+		apply:       strings.ToUpper,
+	})
+	RegisterTransform(simpleTransform{
+		name:        "lower",
+		description: "Lower case: John → john",
+		apply:       strings.ToLower,
+	})
+	RegisterTransform(simpleTransform{
+		name:        "capitalize",
+		description: "Upper case first letter, lower case rest: jOHN → John",
+		apply:       capitalize,
+	})
+	RegisterTransform(simpleTransform{
+		name:        "reverse",
+		description: "Reverse the word: john → nhoj",
+		apply:       reverse,
+	})
+}
+
+var leetMap = map[rune]string{
+	'a': "4",
+	'e': "3",
+	'i': "1",
+	'o': "0",
+	's': "5",
+}
+
+// leetTransform spells words in leet speak with its table, leetMap unless
+// configured with substitutions such as "a=@".
+type leetTransform struct {
+	table map[rune]string
+}
+
+func (leetTransform) Name() string { return "leet" }
+
+func (leetTransform) Description() string {
+	return "Leet speak spelling: a→4, e→3, i→1, o→0, s→5 (leet:a=@,s=$ for another table)"
+}
+
+// substitutions returns the table of t.
+func (t leetTransform) substitutions() map[rune]string {
+	if t.table == nil {
+		return leetMap
+	}
+	return t.table
+}
+
+func (t leetTransform) Apply(word string) iter.Seq[string] {
+	table := t.substitutions()
+	return func(yield func(string) bool) {
+		yield(leetWordWith(word, table))
+	}
+}
+
+func (leetTransform) Configure(args []string) (Transform, error) {
+	table := make(map[rune]string)
+	for _, arg := range args {
+		from, to, ok := strings.Cut(arg, "=")
+		letters := []rune(from)
+		if !ok || len(letters) != 1 || to == "" {
+			return nil, fmt.Errorf("substitutions must look like a=4, got %q", arg)
+		}
+		table[unicode.ToLower(letters[0])] = to
+	}
+	return leetTransform{table: table}, nil
+}
+
+// defaultSuffixes are what the suffix transform appends unless configured
+// otherwise.
+var defaultSuffixes = []string{"1", "12", "123", "!", "1!", "123!"}
+
+// suffixTransform appends each of its suffixes to words.
+type suffixTransform struct {
+	suffixes []string
+}
+
+func (suffixTransform) Name() string { return "suffix" }
+
+func (suffixTransform) Description() string {
+	return fmt.Sprintf("Append common suffixes: john → john123 (%s, or suffix:!,2024 for others)", strings.Join(defaultSuffixes, " "))
+}
+
+func (t suffixTransform) Apply(word string) iter.Seq[string] {
+	suffixes := t.suffixes
+	if suffixes == nil {
+		suffixes = defaultSuffixes
+	}
+	return func(yield func(string) bool) {
+		for _, suffix := range suffixes {
+			if !yield(word + suffix) {
+				return
+			}
+		}
+	}
+}
+
+func (suffixTransform) Configure(args []string) (Transform, error) {
+	return suffixTransform{suffixes: args}, nil
+}
+
+func leetWord(word string) string {
+	return leetWordWith(word, leetMap)
+}
+
+func leetWordWith(word string, table map[rune]string) string {
+	var leet strings.Builder
+	leet.Grow(len(word))
+	for _, char := range word {
+		lowerChar := unicode.ToLower(char)
+		if leetChar, ok := table[lowerChar]; ok {
+			leet.WriteString(leetChar)
+		} else {
+			leet.WriteRune(char)
+		}
+	}
+	return leet.String()
+}
+
+func swapCase(word string) string {
+	var swapped strings.Builder
+	swapped.Grow(len(word))
+	for _, char := range word {
+		if unicode.IsLower(char) {
+			swapped.WriteRune(unicode.ToUpper(char))
+		} else {
+			swapped.WriteRune(unicode.ToLower(char))
+		}
+	}
+	return swapped.String()
+}
+
+func reverse(word string) string {
+	runes := []rune(word)
+	slices.Reverse(runes)
+	return string(runes)
+}
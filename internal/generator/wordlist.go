@@ -0,0 +1,191 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxLineLength is the longest line read from a wordlist.
+const maxLineLength = 1024 * 1024
+
+type wordlistReader struct {
+	io.Reader
+	file *os.File
+}
+
+func (r *wordlistReader) Close() error {
+	switch d := r.Reader.(type) {
+	case io.Closer:
+		d.Close()
+	case interface{ Close() }:
+		d.Close()
+	}
+	return r.file.Close()
+}
+
+// OpenWordlist opens a wordlist for reading, decompressing it when its
+// extension is that of a supported compression.
+func OpenWordlist(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range compressions {
+		if strings.HasSuffix(path, c.ext) {
+			r, err := c.newReader(bufio.NewReader(file))
+			if err != nil {
+				file.Close()
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			return &wordlistReader{Reader: r, file: file}, nil
+		}
+	}
+	return file, nil
+}
+
+// WriteWordlist replaces the wordlist at path with lines, as read by
+// ScanPasswords, after the header of its format and compressed when its
+// extension is that of a supported compression. The lines go to a
+// temporary file first, so the wordlist is kept on error.
+func WriteWordlist(path string, lines []string) error {
+	compress, err := resolveCompression(Options{OutputFilePath: path})
+	if err != nil {
+		return err
+	}
+	f := formats[FormatPlain]
+	f.header = formats[WordlistFormat(path)].header
+	tmp := path + ".tmp"
+	out, err := newFileSink(tmp, compress, f, 0)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if err := out.Write(newCandidate(line, nil)); err != nil {
+			out.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ScanWordlist calls fn for every non-empty line of r, without the line
+// ending. If fn returns an error scanning stops and that error is returned.
+func ScanWordlist(r io.Reader, fn func(word string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		word := strings.TrimSuffix(scanner.Text(), "\r")
+		if word == "" {
+			continue
+		}
+		if err := fn(word); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Merge writes the passwords of the wordlists at paths to the output of opts
+// in order, removing duplicates with the dedup strategy of opts. Every
+// wordlist is read in the format of its extension and only the passwords
+// are kept, so the output has no sources or strength even in the JSON Lines
+// and CSV formats. The output is compressed, split and formatted as asked
+// for in opts; the other options are ignored.
+func Merge(opts Options, paths []string) error {
+	if opts.SplitLines < 0 || opts.SplitMegabytes < 0 {
+		return fmt.Errorf("split size must be a positive number")
+	}
+	compress, err := resolveCompression(opts)
+	if err != nil {
+		return err
+	}
+	f, err := resolveFormat(opts, compress)
+	if err != nil {
+		return err
+	}
+	f.strength = false
+	out, err := openSink(opts, compress, f, 0)
+	if err != nil {
+		return err
+	}
+
+	if err := mergeInto(opts, paths, out); err != nil {
+		out.Close()
+		return err
+	}
+	return closeCompleted(out)
+}
+
+func mergeInto(opts Options, paths []string, out sink) error {
+	dedup, err := newDeduper(opts, out.Write)
+	if err != nil {
+		return err
+	}
+	defer dedup.Close()
+
+	for _, path := range paths {
+		r, err := OpenWordlist(path)
+		if err != nil {
+			return err
+		}
+		err = ScanPasswords(r, WordlistFormat(path), func(password, _ string) error {
+			return dedup.Add(newCandidate(password, nil))
+		})
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return dedup.Flush()
+}
+
+// Size is the expected size of a wordlist.
+type Size struct {
+	Words int64
+	// Bytes is the size of the wordlist as plain text, one word per line,
+	// before compression.
+	Bytes int64
+}
+
+// FormatBytes formats a size in bytes with a binary unit.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// Estimate runs the generation for opts without writing anything and
+// returns the size of the wordlist. Duplicates are removed with a bloom
+// filter, so the count may be low by at most the bloom false positive rate.
+func Estimate(ctx context.Context, opts Options) (Size, error) {
+	g, err := prepare(opts)
+	if err != nil {
+		return Size{}, err
+	}
+
+	opts.Dedup = DedupBloom
+	opts.MarkovRank = false
+	var size Size
+	err = generate(ctx, opts, g, funcSink(func(password string) error {
+		size.Words++
+		size.Bytes += int64(len(password)) + 1
+		return nil
+	}))
+	return size, err
+}
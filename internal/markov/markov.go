@@ -0,0 +1,254 @@
+package markov
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultOrder = 3
+
+	startRune = '\x02'
+	endRune   = '\x03'
+
+	modelVersion = 1
+)
+
+// Model is a character-level Markov chain trained on a password corpus.
+// Each context of Order characters maps to the characters that followed it,
+// sorted by how often they were seen.
+type Model struct {
+	Version     int
+	Order       int
+	Words       uint64
+	Alphabet    int
+	Transitions map[string][]Transition
+}
+
+type Transition struct {
+	Char  rune
+	Count uint32
+}
+
+// Train reads one password per line from r and builds a model of the given order.
+func Train(r io.Reader, order int) (*Model, error) {
+	if order < 1 {
+		return nil, fmt.Errorf("order must be a positive number")
+	}
+
+	counts := make(map[string]map[rune]uint32)
+	alphabet := make(map[rune]struct{})
+	var words uint64
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		word := strings.TrimRight(scanner.Text(), "\r")
+		if word == "" {
+			continue
+		}
+		words++
+
+		context := strings.Repeat(string(startRune), order)
+		for _, char := range word + string(endRune) {
+			next, ok := counts[context]
+			if !ok {
+				next = make(map[rune]uint32)
+				counts[context] = next
+			}
+			next[char]++
+			alphabet[char] = struct{}{}
+			context = shift(context, char)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if words == 0 {
+		return nil, fmt.Errorf("corpus does not contain any passwords")
+	}
+
+	m := &Model{
+		Version:     modelVersion,
+		Order:       order,
+		Words:       words,
+		Alphabet:    len(alphabet),
+		Transitions: make(map[string][]Transition, len(counts)),
+	}
+	for context, next := range counts {
+		transitions := make([]Transition, 0, len(next))
+		for char, count := range next {
+			transitions = append(transitions, Transition{Char: char, Count: count})
+		}
+		sort.Slice(transitions, func(i, j int) bool {
+			if transitions[i].Count != transitions[j].Count {
+				return transitions[i].Count > transitions[j].Count
+			}
+			return transitions[i].Char < transitions[j].Char
+		})
+		m.Transitions[context] = transitions
+	}
+	return m, nil
+}
+
+func shift(context string, char rune) string {
+	runes := []rune(context)
+	return string(append(runes[1:], char))
+}
+
+// Save writes the model as gzip-compressed gob.
+func (m *Model) Save(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if err := gob.NewEncoder(zw).Encode(m); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+func (m *Model) SaveFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := m.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func Load(r io.Reader) (*Model, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a markov model file: %w", err)
+	}
+	defer zr.Close()
+
+	var m Model
+	if err := gob.NewDecoder(zr).Decode(&m); err != nil {
+		return nil, fmt.Errorf("not a markov model file: %w", err)
+	}
+	if m.Version != modelVersion {
+		return nil, fmt.Errorf("unsupported markov model version %d", m.Version)
+	}
+	return &m, nil
+}
+
+func LoadFile(path string) (*Model, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Load(file)
+}
+
+func (m *Model) total(transitions []Transition) uint32 {
+	var total uint32
+	for _, t := range transitions {
+		total += t.Count
+	}
+	return total
+}
+
+// prob returns the add-one smoothed probability of char following context.
+func (m *Model) prob(context string, char rune) float64 {
+	transitions := m.Transitions[context]
+	var count uint32
+	for _, t := range transitions {
+		if t.Char == char {
+			count = t.Count
+			break
+		}
+	}
+	return float64(count+1) / float64(m.total(transitions)+uint32(m.Alphabet)+1)
+}
+
+// Score returns the average log10 probability per character of word,
+// including the end of word marker. Higher is more likely.
+func (m *Model) Score(word string) float64 {
+	context := strings.Repeat(string(startRune), m.Order)
+	var logProb float64
+	var n int
+	for _, char := range word + string(endRune) {
+		logProb += math.Log10(m.prob(context, char))
+		context = shift(context, char)
+		n++
+	}
+	return logProb / float64(n)
+}
+
+// Suffixes returns up to n of the most likely continuations of prefix that
+// are at most maxLen characters long, most likely first.
+func (m *Model) Suffixes(prefix string, n, maxLen int) []string {
+	if n < 1 || maxLen < 1 {
+		return nil
+	}
+
+	type state struct {
+		context string
+		suffix  string
+		logProb float64
+	}
+
+	context := strings.Repeat(string(startRune), m.Order)
+	for _, char := range prefix {
+		context = shift(context, char)
+	}
+
+	beamWidth := n * 4
+	beam := []state{{context: context}}
+	var done []state
+
+	for length := 0; length <= maxLen && len(beam) > 0; length++ {
+		var next []state
+		for _, s := range beam {
+			transitions := m.Transitions[s.context]
+			total := float64(m.total(transitions) + uint32(m.Alphabet) + 1)
+			for _, t := range transitions {
+				logProb := s.logProb + math.Log10(float64(t.Count+1)/total)
+				if t.Char == endRune {
+					if s.suffix != "" {
+						done = append(done, state{suffix: s.suffix, logProb: logProb})
+					}
+					continue
+				}
+				if length == maxLen {
+					continue
+				}
+				next = append(next, state{
+					context: shift(s.context, t.Char),
+					suffix:  s.suffix + string(t.Char),
+					logProb: logProb,
+				})
+			}
+		}
+		sort.SliceStable(next, func(i, j int) bool {
+			return next[i].logProb > next[j].logProb
+		})
+		if len(next) > beamWidth {
+			next = next[:beamWidth]
+		}
+		beam = next
+	}
+
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].logProb > done[j].logProb
+	})
+	result := []string{}
+	for _, s := range done {
+		if len(result) == n {
+			break
+		}
+		result = append(result, s.suffix)
+	}
+	return result
+}
@@ -0,0 +1,429 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+)
+
+// Profile is the personal information of a target and the variations to
+// generate from it, as stored in a profile file.
+type Profile struct {
+	FirstName      string   `json:"firstname"`
+	LastName       string   `json:"lastname"`
+	Birthday       string   `json:"birthday"`
+	Words          []string `json:"words"`
+	People         []Person `json:"people"`
+	Dates          []string `json:"dates"`
+	Phones         []string `json:"phones"`
+	Companies      []string `json:"companies"`
+	Pets           []string `json:"pets"`
+	Places         []string `json:"places"`
+	Sources        []string `json:"sources"`
+	MinLength      int      `json:"min_length"`
+	MaxLength      int      `json:"max_length"`
+	Leet           bool     `json:"leet"`
+	Caps           bool     `json:"caps"`
+	Transforms     []string `json:"transforms"`
+	Separators     []string `json:"separators"`
+	CombineDepth   int      `json:"combine_depth"`
+	Templates      bool     `json:"templates"`
+	TemplateFile   string   `json:"template_file,omitempty"`
+	Markov         string   `json:"markov,omitempty"`
+	MarkovSuffixes int      `json:"markov_suffixes"`
+	MarkovRank     bool     `json:"markov_rank"`
+	MinStrength    int      `json:"min_strength"`
+	// Output is the wordlist file to write, unless given otherwise.
+	Output string `json:"output,omitempty"`
+}
+
+// Person is someone close to the target, such as a partner or a child.
+type Person struct {
+	Name     string `json:"name"`
+	Relation string `json:"relation,omitempty"`
+	Birthday string `json:"birthday,omitempty"`
+}
+
+// Default lengths of the passwords generated.
+const (
+	DefaultMinLength = 6
+	DefaultMaxLength = 12
+)
+
+// DefaultCombineDepth is the most words combined into one password by
+// default.
+const DefaultCombineDepth = 3
+
+// Default returns an empty profile with the default variations.
+func Default() Profile {
+	return Profile{
+		Words:          []string{},
+		People:         []Person{},
+		Dates:          []string{},
+		Phones:         []string{},
+		Companies:      []string{},
+		Pets:           []string{},
+		Places:         []string{},
+		Sources:        []string{},
+		Transforms:     []string{},
+		Separators:     []string{},
+		CombineDepth:   DefaultCombineDepth,
+		MinLength:      DefaultMinLength,
+		MaxLength:      DefaultMaxLength,
+		MarkovSuffixes: 5,
+	}
+}
+
+// Load reads a profile in JSON. Fields missing from r keep their default
+// and unknown fields are an error, so typos do not go unnoticed.
+func Load(r io.Reader) (Profile, error) {
+	p := Default()
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&p); err != nil {
+		return Profile{}, fmt.Errorf("invalid profile: %w", err)
+	}
+	return p, nil
+}
+
+func LoadFile(path string) (Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer file.Close()
+	return Load(file)
+}
+
+func (p Profile) Save(w io.Writer) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func (p Profile) SaveFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := p.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Profile fields, as named in profile files.
+const (
+	FieldFirstName      = "firstname"
+	FieldLastName       = "lastname"
+	FieldBirthday       = "birthday"
+	FieldWords          = "words"
+	FieldPeople         = "people"
+	FieldDates          = "dates"
+	FieldPhones         = "phones"
+	FieldCompanies      = "companies"
+	FieldPets           = "pets"
+	FieldPlaces         = "places"
+	FieldSources        = "sources"
+	FieldMinLength      = "min_length"
+	FieldMaxLength      = "max_length"
+	FieldTransforms     = "transforms"
+	FieldSeparators     = "separators"
+	FieldCombineDepth   = "combine_depth"
+	FieldMarkovSuffixes = "markov_suffixes"
+	FieldMinStrength    = "min_strength"
+)
+
+// FieldError is an invalid value of a profile field.
+type FieldError struct {
+	Field string
+	// Index is the invalid entry of a list field such as people, and Part
+	// the invalid part of the entry, such as the birthday of a person.
+	Index   int
+	Part    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func fieldError(field, format string, args ...any) error {
+	return &FieldError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// fields are the fields Validate checks, in order.
+var fields = []string{
+	FieldFirstName, FieldLastName, FieldBirthday, FieldWords, FieldPeople,
+	FieldDates, FieldPhones, FieldCompanies, FieldPets, FieldPlaces,
+	FieldSources, FieldMinLength, FieldMaxLength, FieldTransforms,
+	FieldSeparators, FieldCombineDepth, FieldMarkovSuffixes, FieldMinStrength,
+}
+
+// Validate reports the first field of the profile that cannot be generated
+// from as a *FieldError. It is the validation shared by the CLI and the TUI.
+func (p Profile) Validate() error {
+	for _, field := range fields {
+		if err := p.ValidateField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateField reports whether field cannot be generated from as a
+// *FieldError, so a part of a profile can be checked before the rest is
+// filled in.
+func (p Profile) ValidateField(field string) error {
+	switch field {
+	case FieldFirstName:
+		if strings.TrimSpace(p.FirstName) == "" {
+			return fieldError(FieldFirstName, "firstname cannot be empty")
+		}
+	case FieldLastName:
+		if strings.TrimSpace(p.LastName) == "" {
+			return fieldError(FieldLastName, "lastname cannot be empty")
+		}
+	case FieldBirthday:
+		return validateDate(FieldBirthday, 0, "", "birthday", p.Birthday)
+	case FieldWords:
+		// A lone entry with spaces is most likely a list separated with
+		// spaces instead of commas; words such as "new york" are fine
+		// among others.
+		if len(p.Words) == 1 && len(strings.Fields(p.Words[0])) > 1 {
+			return listError(FieldWords, 0, "related words must be separated with , (got %q)", p.Words[0])
+		}
+	case FieldPeople:
+		for i, person := range p.People {
+			if strings.TrimSpace(person.Name) == "" {
+				return listError(FieldPeople, i, "the name of person %d cannot be empty", i+1)
+			}
+			if err := validateDate(FieldPeople, i, "birthday", "the birthday of "+person.Name, person.Birthday); err != nil {
+				return err
+			}
+		}
+	case FieldDates:
+		for i, date := range p.Dates {
+			if err := validateDate(FieldDates, i, "", "date", date); err != nil {
+				return err
+			}
+		}
+	case FieldPhones:
+		for i, phone := range p.Phones {
+			if err := validatePhone(i, phone); err != nil {
+				return err
+			}
+		}
+	case FieldCompanies, FieldPets, FieldPlaces:
+		for i, name := range p.names(field) {
+			if strings.TrimSpace(name) == "" {
+				return listError(field, i, "%s cannot have empty entries", field)
+			}
+		}
+	case FieldSources:
+		for i, source := range p.Sources {
+			if _, err := generator.ParseSourceSpec(source); err != nil {
+				return listError(FieldSources, i, "%v", err)
+			}
+		}
+	case FieldMinLength:
+		if p.MinLength < 1 {
+			return fieldError(FieldMinLength, "min password length must be a positive number, got %d", p.MinLength)
+		}
+		if p.MaxLength >= 1 {
+			if err := generator.ValidateLengthRange(p.MinLength, p.MaxLength); err != nil {
+				return fieldError(FieldMinLength, "%v", err)
+			}
+		}
+	case FieldMaxLength:
+		if p.MaxLength < 1 {
+			return fieldError(FieldMaxLength, "max password length must be a positive number, got %d", p.MaxLength)
+		}
+	case FieldTransforms:
+		for i, spec := range p.Transforms {
+			if _, err := generator.ParseTransform(spec); err != nil {
+				return listError(FieldTransforms, i, "%v", err)
+			}
+		}
+	case FieldSeparators:
+		for i, sep := range p.Separators {
+			if sep == "" || strings.ContainsFunc(sep, unicode.IsSpace) {
+				return listError(FieldSeparators, i, "separators cannot be empty or contain spaces, got %q", sep)
+			}
+		}
+	case FieldCombineDepth:
+		if err := generator.ValidateCombineDepth(p.CombineDepth); err != nil {
+			return fieldError(FieldCombineDepth, "%v", err)
+		}
+	case FieldMarkovSuffixes:
+		if err := generator.ValidateMarkovSuffixes(p.MarkovSuffixes); err != nil {
+			return fieldError(FieldMarkovSuffixes, "%v", err)
+		}
+	case FieldMinStrength:
+		if err := generator.ValidateMinStrength(p.MinStrength); err != nil {
+			return fieldError(FieldMinStrength, "%v", err)
+		}
+	}
+	return nil
+}
+
+func listError(field string, index int, format string, args ...any) error {
+	return &FieldError{Field: field, Index: index, Message: fmt.Sprintf(format, args...)}
+}
+
+// names returns the companies, pets or places of the profile.
+func (p Profile) names(field string) []string {
+	switch field {
+	case FieldCompanies:
+		return p.Companies
+	case FieldPets:
+		return p.Pets
+	case FieldPlaces:
+		return p.Places
+	}
+	return nil
+}
+
+// validateDate checks a date of field, named what in messages, which may be
+// left empty.
+func validateDate(field string, index int, part, what, date string) error {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return nil
+	}
+	for _, char := range date {
+		if !(char == '/' || (char >= '0' && char <= '9')) {
+			return &FieldError{Field: field, Index: index, Part: part, Message: fmt.Sprintf("%s can only contain numbers and /, got %q", what, date)}
+		}
+	}
+	if !strings.Contains(date, "/") {
+		return &FieldError{Field: field, Index: index, Part: part, Message: fmt.Sprintf("%s parts must be separated with / (e.g. DD/MM/YYYY), got %q", what, date)}
+	}
+	return nil
+}
+
+func validatePhone(index int, phone string) error {
+	digits := 0
+	for _, char := range phone {
+		switch {
+		case char >= '0' && char <= '9':
+			digits++
+		case strings.ContainsRune(" +-().", char):
+		default:
+			return listError(FieldPhones, index, "phone numbers can only contain numbers, spaces and + - ( ) ., got %q", phone)
+		}
+	}
+	if digits < 3 {
+		return listError(FieldPhones, index, "phone number %q is too short", phone)
+	}
+	return nil
+}
+
+// ParseLength parses a password length typed into field, which may be left
+// empty for the default.
+func ParseLength(field, s string, defaultLength int) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return defaultLength, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		name := "min"
+		if field == FieldMaxLength {
+			name = "max"
+		}
+		return 0, fieldError(field, "%s password length must be a number, got %q", name, s)
+	}
+	return n, nil
+}
+
+// Options returns the generator options for the profile.
+func (p Profile) Options() generator.Options {
+	var birthday []string
+	if p.Birthday != "" {
+		birthday = strings.Split(p.Birthday, "/")
+	}
+
+	var words []string
+	for _, word := range p.Words {
+		if trimmed := strings.TrimSpace(word); trimmed != "" {
+			words = append(words, trimmed)
+		}
+	}
+
+	// People, companies, pets and places are related words too, names of
+	// several words both split and joined.
+	for _, person := range p.People {
+		words = append(words, strings.Fields(person.Name)...)
+	}
+	for _, field := range []string{FieldCompanies, FieldPets, FieldPlaces} {
+		for _, name := range p.names(field) {
+			if joined := strings.Join(strings.Fields(name), ""); joined != "" {
+				words = append(words, joined)
+			}
+		}
+	}
+
+	var sources []generator.SourceSpec
+	dates := slices.Clone(p.Dates)
+	for _, person := range p.People {
+		dates = append(dates, person.Birthday)
+	}
+	for _, date := range dates {
+		if date = strings.TrimSpace(date); date != "" {
+			sources = append(sources, generator.SourceSpec{Name: "birthday", Args: strings.Split(date, "/")})
+		}
+	}
+	if len(p.Phones) > 0 {
+		sources = append(sources, generator.SourceSpec{Name: "phone", Args: p.Phones})
+	}
+	for _, source := range p.Sources {
+		if spec, err := generator.ParseSourceSpec(source); err == nil {
+			sources = append(sources, spec)
+		}
+	}
+
+	return generator.Options{
+		InputFirstName:    strings.Fields(p.FirstName),
+		InputLastName:     strings.Fields(p.LastName),
+		InputBirthday:     birthday,
+		InputRelatedWords: words,
+		MinLength:         p.MinLength,
+		MaxLength:         p.MaxLength,
+		EnableLeet:        p.Leet,
+		EnableCapitalize:  p.Caps,
+		Transforms:        p.Transforms,
+		Separators:        p.Separators,
+		CombineDepth:      p.CombineDepth,
+		EnableTemplates:   p.Templates,
+		TemplateFilePath:  p.TemplateFile,
+		MarkovModelPath:   p.Markov,
+		MarkovSuffixes:    p.MarkovSuffixes,
+		MarkovRank:        p.MarkovRank,
+		MinStrength:       p.MinStrength,
+		Sources:           sources,
+		OutputFilePath:    p.Output,
+	}
+}
+
+// SplitWords splits related words given as a comma separated list.
+func SplitWords(s string) []string {
+	words := []string{}
+	for _, word := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(word); trimmed != "" {
+			words = append(words, trimmed)
+		}
+	}
+	return words
+}
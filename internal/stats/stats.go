@@ -0,0 +1,170 @@
+package stats
+
+import (
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+)
+
+// Charset classes a word can contain.
+const (
+	Lower  = "lower"
+	Upper  = "upper"
+	Digit  = "digit"
+	Symbol = "symbol"
+)
+
+// Stats describes the words of a wordlist.
+type Stats struct {
+	Words int64
+	// Unique counts the distinct words, compared by a 64 bit hash.
+	Unique    int64
+	Bytes     int64
+	MinLength int
+	MaxLength int
+	// Lengths counts the words of every length in characters.
+	Lengths map[int]int64
+	// Charsets counts the words by the classes of characters they contain,
+	// e.g. "lower+digit".
+	Charsets map[string]int64
+	// Strength counts the words by strength score, when asked for.
+	Strength []int64
+}
+
+// Count is a value and how many words have it.
+type Count struct {
+	Value string
+	Words int64
+}
+
+// Collect reads a wordlist in format, one of the generator formats, and
+// returns the stats of its passwords. With rateStrength every word also gets
+// a strength estimate, which is slower.
+func Collect(r io.Reader, format string, rateStrength bool) (Stats, error) {
+	s := Stats{
+		Lengths:  make(map[int]int64),
+		Charsets: make(map[string]int64),
+	}
+	if rateStrength {
+		s.Strength = make([]int64, 5)
+	}
+	seen := make(map[uint64]struct{})
+
+	err := generator.ScanPasswords(r, format, func(word, _ string) error {
+		s.Add(word)
+		h := fnv.New64a()
+		h.Write([]byte(word))
+		seen[h.Sum64()] = struct{}{}
+		if rateStrength {
+			s.Strength[strength.Estimate(word).Score]++
+		}
+		return nil
+	})
+	s.Unique = int64(len(seen))
+	return s, err
+}
+
+// Add counts word in every stat but Unique and Strength.
+func (s *Stats) Add(word string) {
+	length := utf8.RuneCountInString(word)
+	if s.Words == 0 || length < s.MinLength {
+		s.MinLength = length
+	}
+	if length > s.MaxLength {
+		s.MaxLength = length
+	}
+	s.Words++
+	s.Bytes += int64(len(word)) + 1
+	s.Lengths[length]++
+	s.Charsets[Charset(word)]++
+}
+
+// Remove takes back a word counted with Add.
+func (s *Stats) Remove(word string) {
+	length := utf8.RuneCountInString(word)
+	s.Words--
+	s.Bytes -= int64(len(word)) + 1
+	charset := Charset(word)
+	if s.Charsets[charset]--; s.Charsets[charset] == 0 {
+		delete(s.Charsets, charset)
+	}
+	if s.Lengths[length]--; s.Lengths[length] > 0 {
+		return
+	}
+
+	// The last word of its length may have been the shortest or longest.
+	delete(s.Lengths, length)
+	s.MinLength, s.MaxLength = 0, 0
+	first := true
+	for length := range s.Lengths {
+		if first || length < s.MinLength {
+			s.MinLength = length
+		}
+		if first || length > s.MaxLength {
+			s.MaxLength = length
+		}
+		first = false
+	}
+}
+
+// AverageLength returns the average word length in characters.
+func (s Stats) AverageLength() float64 {
+	if s.Words == 0 {
+		return 0
+	}
+	var total int64
+	for length, words := range s.Lengths {
+		total += int64(length) * words
+	}
+	return float64(total) / float64(s.Words)
+}
+
+// SortedCharsets returns the charset classes, most common first.
+func (s Stats) SortedCharsets() []Count {
+	counts := make([]Count, 0, len(s.Charsets))
+	for charset, words := range s.Charsets {
+		counts = append(counts, Count{Value: charset, Words: words})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Words != counts[j].Words {
+			return counts[i].Words > counts[j].Words
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	return counts
+}
+
+// Charset returns the classes of characters in word joined with +, in the
+// order lower, upper, digit, symbol.
+func Charset(word string) string {
+	var lower, upper, digit, symbol bool
+	for _, char := range word {
+		switch {
+		case unicode.IsLower(char):
+			lower = true
+		case unicode.IsUpper(char):
+			upper = true
+		case unicode.IsDigit(char):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	var classes []string
+	for _, class := range []struct {
+		name string
+		has  bool
+	}{{Lower, lower}, {Upper, upper}, {Digit, digit}, {Symbol, symbol}} {
+		if class.has {
+			classes = append(classes, class.name)
+		}
+	}
+	return strings.Join(classes, "+")
+}
@@ -0,0 +1,417 @@
+package strength
+
+import (
+	_ "embed"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Result is the estimated strength of a password.
+type Result struct {
+	// Guesses is the estimated number of guesses an attacker needs.
+	Guesses float64
+	// Score rates the password from 0 (too guessable) to 4 (very
+	// unguessable), on the same scale as zxcvbn.
+	Score int
+}
+
+// Log10 returns the base 10 logarithm of the number of guesses.
+func (r Result) Log10() float64 {
+	return math.Log10(r.Guesses)
+}
+
+// Entropy returns the estimate in bits.
+func (r Result) Entropy() float64 {
+	return math.Log2(r.Guesses)
+}
+
+//go:embed common.txt
+var commonPasswords string
+
+// dictionary maps common passwords and words to their popularity rank,
+// which is used as the number of guesses needed to find them.
+var dictionary = func() map[string]int {
+	d := make(map[string]int)
+	for i, word := range strings.Fields(commonPasswords) {
+		d[word] = i + 1
+	}
+	return d
+}()
+
+var unleet = map[rune]rune{
+	'4': 'a',
+	'@': 'a',
+	'3': 'e',
+	'1': 'i',
+	'!': 'i',
+	'0': 'o',
+	'5': 's',
+	'$': 's',
+	'7': 't',
+}
+
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+var shiftedKeys = map[rune]rune{
+	'~': '`', '!': '1', '@': '2', '#': '3', '$': '4', '%': '5', '^': '6',
+	'&': '7', '*': '8', '(': '9', ')': '0', '_': '-', '+': '=', '{': '[',
+	'}': ']', '|': '\\', ':': ';', '"': '\'', '<': ',', '>': '.', '?': '/',
+}
+
+type key struct{ row, col int }
+
+var keyPositions = func() map[rune]key {
+	positions := make(map[rune]key)
+	for row, keys := range keyboardRows {
+		for col, char := range keys {
+			positions[char] = key{row, col}
+		}
+	}
+	return positions
+}()
+
+const (
+	keyboardStartingPositions = 94
+	keyboardAverageDegree     = 4.6
+	bruteforceCardinality     = 10
+	minYear                   = 1900
+	maxYear                   = 2050
+	referenceYear             = 2025
+	minYearSpace              = 20
+)
+
+type match struct {
+	start, end int
+	guesses    float64
+}
+
+// Estimate returns the strength of password, found as the cheapest way to
+// cover it with dictionary words (including leet and case variants), keyboard
+// walks, sequences, repeats, dates and brute force.
+func Estimate(password string) Result {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return Result{Guesses: 1, Score: 0}
+	}
+
+	var matches []match
+	matches = append(matches, dictionaryMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, dateMatches(runes)...)
+
+	guesses := cheapestCover(len(runes), matches)
+	return Result{Guesses: guesses, Score: score(guesses)}
+}
+
+func score(guesses float64) int {
+	switch {
+	case guesses < 1e3:
+		return 0
+	case guesses < 1e6:
+		return 1
+	case guesses < 1e8:
+		return 2
+	case guesses < 1e10:
+		return 3
+	}
+	return 4
+}
+
+// cheapestCover finds the sequence of matches and brute forced gaps covering
+// the password with the fewest guesses. Like zxcvbn it multiplies the guesses
+// of the pieces and the factorial of their count, since an attacker has to
+// try the pieces in every order.
+func cheapestCover(n int, matches []match) float64 {
+	byEnd := make([][]match, n+1)
+	for _, m := range matches {
+		byEnd[m.end] = append(byEnd[m.end], m)
+	}
+
+	// best[j][k] is the fewest guesses covering the first j runes with k pieces.
+	best := make([][]float64, n+1)
+	for j := range best {
+		best[j] = make([]float64, n+1)
+		for k := range best[j] {
+			best[j][k] = math.Inf(1)
+		}
+	}
+	best[0][0] = 1
+
+	for j := 1; j <= n; j++ {
+		for k := 1; k <= j; k++ {
+			for i := 0; i < j; i++ {
+				if math.IsInf(best[i][k-1], 1) {
+					continue
+				}
+				bruteforce := math.Max(math.Pow(bruteforceCardinality, float64(j-i)), float64(j-i)+1)
+				best[j][k] = math.Min(best[j][k], best[i][k-1]*bruteforce)
+			}
+			for _, m := range byEnd[j] {
+				if !math.IsInf(best[m.start][k-1], 1) {
+					best[j][k] = math.Min(best[j][k], best[m.start][k-1]*m.guesses)
+				}
+			}
+		}
+	}
+
+	guesses := math.Inf(1)
+	factorial := 1.0
+	for k := 1; k <= n; k++ {
+		factorial *= float64(k)
+		guesses = math.Min(guesses, best[n][k]*factorial)
+	}
+	return guesses
+}
+
+func dictionaryMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i < len(runes); i++ {
+		for j := i + 3; j <= len(runes); j++ {
+			token := runes[i:j]
+			lower := strings.ToLower(string(token))
+			if rank, ok := dictionary[lower]; ok {
+				matches = append(matches, match{i, j, float64(rank) * caseVariations(token)})
+			}
+
+			plain, substitutions := unleetToken(lower)
+			if substitutions > 0 {
+				if rank, ok := dictionary[plain]; ok {
+					leetFactor := math.Pow(2, float64(substitutions))
+					matches = append(matches, match{i, j, float64(rank) * caseVariations(token) * leetFactor})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+func unleetToken(token string) (string, int) {
+	var b strings.Builder
+	substitutions := 0
+	for _, char := range token {
+		if plain, ok := unleet[char]; ok {
+			b.WriteRune(plain)
+			substitutions++
+		} else {
+			b.WriteRune(char)
+		}
+	}
+	return b.String(), substitutions
+}
+
+// caseVariations returns how many guesses the capitalization of a word adds.
+func caseVariations(token []rune) float64 {
+	upper, lower := 0, 0
+	for _, char := range token {
+		if unicode.IsUpper(char) {
+			upper++
+		} else if unicode.IsLower(char) {
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || (upper == 1 && unicode.IsUpper(token[0])) {
+		return 2
+	}
+	variations := 0.0
+	for i := 1; i <= min(upper, lower); i++ {
+		variations += binomial(upper+lower, i)
+	}
+	return variations
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func keyPosition(char rune) (key, bool) {
+	if plain, ok := shiftedKeys[char]; ok {
+		char = plain
+	}
+	k, ok := keyPositions[unicode.ToLower(char)]
+	return k, ok
+}
+
+func adjacent(a, b rune) (direction key, ok bool) {
+	ka, okA := keyPosition(a)
+	kb, okB := keyPosition(b)
+	if !okA || !okB {
+		return key{}, false
+	}
+	dr, dc := kb.row-ka.row, kb.col-ka.col
+	// Keyboard rows are staggered, so the keys diagonally below are at the
+	// same and previous column.
+	if (dr == 0 && (dc == 1 || dc == -1)) || (dr == 1 && (dc == 0 || dc == -1)) || (dr == -1 && (dc == 0 || dc == 1)) {
+		return key{dr, dc}, true
+	}
+	return key{}, false
+}
+
+func keyboardMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i < len(runes)-2; i++ {
+		turns := 1
+		var previous key
+		for j := i + 1; j < len(runes); j++ {
+			direction, ok := adjacent(runes[j-1], runes[j])
+			if !ok {
+				break
+			}
+			if j > i+1 && direction != previous {
+				turns++
+			}
+			previous = direction
+			if length := j - i + 1; length >= 3 {
+				guesses := keyboardStartingPositions * float64(length) * math.Pow(keyboardAverageDegree, float64(turns))
+				matches = append(matches, match{i, j + 1, guesses})
+			}
+		}
+	}
+	return matches
+}
+
+func sequenceMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i < len(runes)-2; i++ {
+		delta := runes[i+1] - runes[i]
+		if delta != 1 && delta != -1 {
+			continue
+		}
+		j := i + 1
+		for j+1 < len(runes) && runes[j+1]-runes[j] == delta {
+			j++
+		}
+		if length := j - i + 1; length >= 3 {
+			base := 26.0
+			switch first := unicode.ToLower(runes[i]); {
+			case first == 'a' || first == 'z' || first == '0' || first == '1' || first == '9':
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, match{i, j + 1, base * float64(length)})
+		}
+	}
+	return matches
+}
+
+func repeatMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		if j-i >= 3 {
+			matches = append(matches, match{i, j, 26 * float64(j-i)})
+		}
+		i = j
+	}
+	return matches
+}
+
+// dateMatches finds runs of 4 to 8 digits that read as a year or a date.
+func dateMatches(runes []rune) []match {
+	var matches []match
+	for i := 0; i < len(runes); i++ {
+		for j := i + 4; j <= len(runes) && j-i <= 8; j++ {
+			digits := string(runes[i:j])
+			if !isDigits(digits) {
+				break
+			}
+			if year, ok := readYear(digits); ok && len(digits) == 4 {
+				matches = append(matches, match{i, j, yearSpace(year)})
+			}
+			if year, ok := readDate(digits); ok {
+				matches = append(matches, match{i, j, 365 * yearSpace(year)})
+			}
+		}
+	}
+	return matches
+}
+
+func isDigits(s string) bool {
+	for _, char := range s {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func yearSpace(year int) float64 {
+	return math.Max(math.Abs(float64(year-referenceYear)), minYearSpace)
+}
+
+func readYear(digits string) (int, bool) {
+	year, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false
+	}
+	if len(digits) == 2 {
+		if year > 50 {
+			return 1900 + year, true
+		}
+		return 2000 + year, true
+	}
+	return year, len(digits) == 4 && year >= minYear && year <= maxYear
+}
+
+// readDate reads digits as day, month and year in either order, with a two
+// or four digit year, e.g. 030791, 1991073 or 03071991.
+func readDate(digits string) (int, bool) {
+	validDay := func(s string) bool {
+		d, err := strconv.Atoi(s)
+		return err == nil && d >= 1 && d <= 31
+	}
+	validMonth := func(s string) bool {
+		m, err := strconv.Atoi(s)
+		return err == nil && m >= 1 && m <= 12
+	}
+
+	for _, yearLength := range []int{4, 2} {
+		rest := len(digits) - yearLength
+		if rest != 4 && !(rest == 2 && yearLength == 4) {
+			continue
+		}
+		for _, yearFirst := range []bool{false, true} {
+			yearDigits, dayMonth := digits[rest:], digits[:rest]
+			if yearFirst {
+				yearDigits, dayMonth = digits[:yearLength], digits[yearLength:]
+			}
+			year, ok := readYear(yearDigits)
+			if !ok {
+				continue
+			}
+			if len(dayMonth) == 2 {
+				if validMonth(dayMonth) {
+					return year, true
+				}
+				continue
+			}
+			a, b := dayMonth[:2], dayMonth[2:]
+			if (validDay(a) && validMonth(b)) || (validMonth(a) && validDay(b)) {
+				return year, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -3,28 +3,14 @@ package tui
 import (
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
-)
-
-const (
-	inputFirstName = iota
-	inputLastName
-	inputBirthday
-	inputRelatedWords
-	inputMinLength
-	inputMaxLength
-	inputOutputFilePath
-	focusLeetBox
-	focusCapitalizeBox
-	focusSubmitButton
-	numInputs
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
 )
 
 var (
@@ -45,46 +31,47 @@ var (
 	errorInputStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true).Underline(true)
 )
 
-type model struct {
-	focusIndex       int
-	inputs           []textinput.Model
-	enableLeet       bool
-	enableCapitalize bool
-	errMsg           string
-	done             bool
-	width            int
-}
-
-type inputConfig struct {
-	placeholder string
-	focused     bool
-}
+const defaultInputWidth = 40
 
-var inputConfigs = []inputConfig{
-	{placeholder: "firstname (and secondname if you want)", focused: true},
-	{placeholder: "lastname", focused: false},
-	{placeholder: "birthday (optional, DD/MM/YYYY or similar, use / to separate)", focused: false},
-	{placeholder: "related words that you want to add (optional, separate with , if you enter more than one)", focused: false},
-	{placeholder: "min password length (optional, default 6)", focused: false},
-	{placeholder: "max password length (optional, default 12)", focused: false},
-	{placeholder: "output file path (optional, default ./wordlist.txt)", focused: false},
+type model struct {
+	// pages are the steps of the wizard, followed by the review step when
+	// page is len(pages).
+	pages      []*page
+	page       int
+	focusIndex int
+	errMsg     string
+	width      int
+	height     int
+	inputWidth int
+	// transforms is shown instead of the wizard while configuring.
+	transforms  transformPanel
+	configuring bool
+	// generation is the run in progress, result the last one finished.
+	generation *generation
+	result     *generationResult
+	// viewer browses the wordlist of the result when opened.
+	viewer  *viewer
+	preview preview
+
+	// base is the last profile loaded, which the form is typed over, and
+	// profilePath the file it was loaded from or last saved to.
+	base        profile.Profile
+	profilePath string
+	picking     bool
+	picker      filepicker.Model
+	saving      bool
+	saveInput   textinput.Model
+	// overwrite is the existing file the save dialog asked to confirm
+	// replacing.
+	overwrite string
+	statusMsg string
 }
 
 func NewModel() *model {
-	m := &model{
-		inputs: make([]textinput.Model, inputOutputFilePath+1),
-	}
-
-	for i, config := range inputConfigs {
-		t := textinput.New()
-		t.PromptStyle = focusedStyle
-		t.PlaceholderStyle = placeholderStyle
-		t.Placeholder = config.placeholder
-		if config.focused {
-			t.Focus()
-		}
-		m.inputs[i] = t
-	}
+	m := &model{inputWidth: defaultInputWidth}
+	m.setProfile(profile.Default())
+	m.setFocus(0)
+	m.schedulePreview()
 	return m
 }
 
@@ -96,313 +83,336 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
-		inputWidth := m.width / 2
-		if inputWidth < 20 {
-			inputWidth = 20
-		}
-		if inputWidth > 80 {
-			inputWidth = 80
+		m.height = msg.Height
+		m.inputWidth = m.width / 2
+		if m.inputWidth < 20 {
+			m.inputWidth = 20
 		}
-		for i := range m.inputs {
-			m.inputs[i].Width = inputWidth
+		if m.inputWidth > 80 {
+			m.inputWidth = 80
 		}
-		return m, nil
-	case tea.KeyMsg:
-		s := msg.String()
-		if m.done {
-			switch s {
-			case "enter":
-				relatedWordsInput := strings.Split(m.inputs[inputRelatedWords].Value(), ",")
-				trimmedRelatedWords := make([]string, 0, len(relatedWordsInput))
-				for _, word := range relatedWordsInput {
-					if trimmed := strings.TrimSpace(word); trimmed != "" {
-						trimmedRelatedWords = append(trimmedRelatedWords, trimmed)
+		for _, pg := range m.pages {
+			for _, g := range pg.groups {
+				for _, row := range g.entries {
+					for i := range row {
+						row[i].Width = m.inputWidth
 					}
 				}
-				opts := generator.Options{
-					InputFirstName:    strings.Fields(m.inputs[inputFirstName].Value()),
-					InputLastName:     strings.Fields(m.inputs[inputLastName].Value()),
-					InputBirthday:     strings.Split(m.inputs[inputBirthday].Value(), "/"),
-					InputRelatedWords: trimmedRelatedWords,
-					InputMinLength:    m.inputs[inputMinLength].Value(),
-					InputMaxLength:    m.inputs[inputMaxLength].Value(),
-					OutputFilePath:    m.inputs[inputOutputFilePath].Value(),
-					EnableLeet:        m.enableLeet,
-					EnableCapitalize:  m.enableCapitalize,
-				}
-				err := generator.Run(opts)
-				if err != nil {
-					m.errMsg = fmt.Sprintf("could not generate password: %v", err)
-					m.done = false
-					return m, nil
-				}
-				return m, tea.Quit
-			case "b", "backspace":
-				m.done = false
-				m.focusIndex = 0
-				return m, nil
 			}
-			return m, nil
 		}
-		switch s {
-		case "ctrl+c", "q", "ctrl+d", "esc":
-			return m, tea.Quit
-		case "tab", "shift+tab", "enter", "up", "down":
-			s := msg.String()
-
-			if s == "enter" && m.focusIndex == focusLeetBox {
-				m.enableLeet = !m.enableLeet
-				return m, nil
-			} else if s == "enter" && m.focusIndex == focusCapitalizeBox {
-				m.enableCapitalize = !m.enableCapitalize
-				return m, nil
-			}
-
-			if s == "enter" && m.focusIndex == focusSubmitButton {
-				if idx, err := validateInputs(m.inputs); err != nil {
-					m.errMsg = err.Error()
-					m.focusIndex = idx
-					cmds := make([]tea.Cmd, len(m.inputs))
-					for i := range m.inputs {
-						if i == m.focusIndex {
-							cmds[i] = m.inputs[i].Focus()
-						} else {
-							m.inputs[i].Blur()
-						}
-					}
-					return m, tea.Batch(cmds...)
-				}
-				m.done = true
-				return m, nil
-			} else {
-				m.errMsg = ""
-			}
-
-			if s == "up" || s == "shift+tab" {
-				m.focusIndex--
-			} else {
-				m.focusIndex++
-			}
-
-			if m.focusIndex >= numInputs {
-				m.focusIndex = 0
-			} else if m.focusIndex < 0 {
-				m.focusIndex = numInputs - 1
+		for _, item := range m.transforms.items {
+			if item.args != nil {
+				item.args.Width = m.inputWidth
 			}
+		}
+		m.transforms.separators.Width = m.inputWidth
+		return m, nil
+	case previewTickMsg, previewMsg:
+		return m, m.updatePreview(msg)
+	}
+	if m.viewer != nil {
+		return m.updateViewer(msg)
+	}
+	if m.generation != nil {
+		return m.updateGeneration(msg)
+	}
+	if m.picking {
+		return m.updatePicker(msg)
+	}
+	if m.saving {
+		return m.updateSaveDialog(msg)
+	}
+	if m.configuring {
+		return m.updateTransforms(msg)
+	}
 
-			cmds := make([]tea.Cmd, len(m.inputs))
-			for i := range m.inputs {
-				if i == m.focusIndex && m.focusIndex < numInputs {
-					cmds[i] = m.inputs[i].Focus()
-				} else if i < len(m.inputs) {
-					m.inputs[i].Blur()
-				}
-			}
-			return m, tea.Batch(cmds...)
-		case "ctrl+r":
-			for i := range m.inputs {
-				if m.inputs[i].Value() != "" {
-					m.inputs[i].Reset()
-				}
-			}
-			m.focusIndex = 0
-
-			cmds := make([]tea.Cmd, len(m.inputs))
-			for i := range m.inputs {
-				if i == m.focusIndex {
-					cmds[i] = m.inputs[i].Focus()
-				} else {
-					m.inputs[i].Blur()
-				}
-			}
-			m.errMsg = ""
-			return m, tea.Batch(cmds...)
+	switch msg := msg.(type) {
+	case progressMsg, tickMsg:
+		// Left over from a finished generation.
+		return m, nil
+	case tea.KeyMsg:
+		if m.result != nil {
+			return m.updateResult(msg)
+		}
+		if cmd, ok := m.handleKey(msg); ok {
+			return m, tea.Batch(cmd, m.schedulePreview())
 		}
 	}
 
-	cmds := make([]tea.Cmd, len(m.inputs))
-	for i := range m.inputs {
-		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	var cmd tea.Cmd
+	if input := m.focusedInput(); input != nil {
+		*input, cmd = input.Update(msg)
 	}
-	return m, tea.Batch(cmds...)
+	return m, tea.Batch(cmd, m.schedulePreview())
 }
 
-func isValidBirthday(birthday string) bool {
-	for _, char := range birthday {
-		if !(char == '/' || (char >= '0' && char <= '9')) {
-			return false
+// handleKey handles the keys of the wizard, and reports whether it did.
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+d", "esc":
+		return tea.Quit, true
+	case "ctrl+o":
+		return m.openPicker(), true
+	case "ctrl+s":
+		return m.openSaveDialog(), true
+	case "ctrl+t":
+		return m.openTransforms(0), true
+	case "ctrl+n", "pgdown":
+		return m.nextPage(), true
+	case "ctrl+p", "pgup":
+		return m.prevPage(), true
+	case "ctrl+x":
+		return m.removeFocusedEntry(), true
+	case "ctrl+r":
+		m.setProfile(profile.Default())
+		m.profilePath = ""
+		m.statusMsg = ""
+		return m.showPage(0), true
+	case "tab", "down":
+		m.errMsg, m.statusMsg = "", ""
+		return m.setFocus(m.focusIndex + 1), true
+	case "shift+tab", "up":
+		m.errMsg, m.statusMsg = "", ""
+		return m.setFocus(m.focusIndex - 1), true
+	case "enter":
+		return m.activate(), true
+	}
+	if m.reviewing() {
+		switch msg.String() {
+		case "q":
+			return tea.Quit, true
+		case "b", "backspace":
+			return m.prevPage(), true
 		}
 	}
-	return true
+	return nil, false
 }
 
-func validateInputs(inputs []textinput.Model) (int, error) {
-	if strings.TrimSpace(inputs[inputFirstName].Value()) == "" {
-		return 0, fmt.Errorf("firstname cannot be empty")
+// activate acts on the target in focus.
+func (m *model) activate() tea.Cmd {
+	t := m.focused()
+	switch t.kind {
+	case targetInput:
+		return m.setFocus(m.focusIndex + 1)
+	case targetAdd:
+		return m.addAnother(t.group)
+	case targetTransforms:
+		return m.openTransforms(0)
+	case targetBack:
+		return m.prevPage()
+	case targetNext:
+		if !m.reviewing() {
+			return m.nextPage()
+		}
+		p, err := m.profile()
+		if err != nil {
+			return m.showError(err)
+		}
+		m.errMsg, m.statusMsg = "", ""
+		return m.startGeneration(p.Options())
 	}
+	return nil
+}
 
-	if strings.TrimSpace(inputs[inputLastName].Value()) == "" {
-		return 1, fmt.Errorf("lastname cannot be empty")
+func (m *model) View() string {
+	localFormStyle := formStyle.Width(m.width/2 + 6)
+	if m.generation != nil {
+		return localFormStyle.Render(m.generationView())
 	}
-
-	birthday := strings.TrimSpace(inputs[inputBirthday].Value())
-	if birthday != "" && !strings.Contains(birthday, "/") {
-		return 2, fmt.Errorf("birthday must be seperated with /(format doesnt matter)")
+	if m.viewer != nil {
+		return m.viewerView()
+	}
+	if m.result != nil {
+		return localFormStyle.Render(m.resultView())
+	}
+	if m.picking {
+		return localFormStyle.Render(m.pickerView())
 	}
-	if birthday != "" && !isValidBirthday(birthday) {
-		return 2, fmt.Errorf("birthday can only contain numbers and /")
+	if m.configuring {
+		return m.withPreview(localFormStyle.Render(m.transformsView()))
 	}
 
-	relatedWords := strings.TrimSpace(inputs[inputRelatedWords].Value())
-	if relatedWords != "" {
-		words := strings.Fields(relatedWords)
-		if len(words) > 1 && !strings.Contains(relatedWords, ",") {
-			return 3, fmt.Errorf("related words must be seperated with ,")
-		}
+	var b strings.Builder
+	title := "Review"
+	if !m.reviewing() {
+		title = m.pages[m.page].spec.title
 	}
+	b.WriteString(focusedStyle.Render(title))
+	b.WriteString(placeholderStyle.Render(fmt.Sprintf("  step %d of %d", m.page+1, len(m.pages)+1)) + "\n")
 
-	minLength := strings.TrimSpace(inputs[inputMinLength].Value())
-	if minLength != "" {
-		minLen, err := strconv.Atoi(minLength)
-		if err != nil || minLen < 1 {
-			return 4, fmt.Errorf("min password length must be a positive number")
-		}
+	if m.reviewing() {
+		b.WriteString(m.reviewView())
+	} else {
+		b.WriteString(m.pageView())
 	}
 
-	maxLength := strings.TrimSpace(inputs[inputMaxLength].Value())
-	if maxLength != "" {
-		maxLen, err := strconv.Atoi(maxLength)
-		if err != nil || maxLen < 1 {
-			return 5, fmt.Errorf("max password length must be a positive number")
-		}
+	if m.errMsg != "" {
+		b.WriteString(errorStyle.Render("\n" + m.errMsg + "\n"))
+	}
+	if m.statusMsg != "" {
+		b.WriteString(successStyle.Render("\n" + m.statusMsg + "\n"))
 	}
+	b.WriteString("\n" + m.buttonsView() + "\n")
 
-	if minLength != "" && maxLength != "" {
-		minLen, _ := strconv.Atoi(minLength)
-		maxLen, _ := strconv.Atoi(maxLength)
-		if minLen > maxLen {
-			return 4, fmt.Errorf("min password length cannot be greater than max password length")
+	help := "tab/shift+tab to move, enter to select, ctrl+n/ctrl+p for the next/previous step, ctrl+x to remove an entry, ctrl+t for the transforms, ctrl+r to clear, ctrl+o to load a profile, ctrl+s to save it, esc to quit"
+	if m.reviewing() {
+		help = "enter to generate, b to go back and edit, ctrl+o to load a profile, ctrl+s to save it, esc to quit"
+	}
+	helpView := placeholderStyle.Render("\n(" + help + ")\n")
+	if m.saving {
+		saveHelp := placeholderStyle.Render("\n(enter to save, esc to cancel)\n")
+		if m.overwrite != "" {
+			saveHelp = errorStyle.Render("\n"+m.overwrite+" already exists") + placeholderStyle.Render(" (enter to overwrite it, esc to cancel)\n")
 		}
+		helpView = "\n" + m.saveInput.View() + saveHelp
 	}
-	return -1, nil
+
+	return m.withPreview(localFormStyle.Render(b.String() + helpView))
 }
 
-func (m *model) setInputStyles() {
-	for i := range m.inputs {
-		// Reset to default (no style)
-		m.inputs[i].PromptStyle = lipgloss.NewStyle()
-		m.inputs[i].TextStyle = lipgloss.NewStyle()
-		m.inputs[i].PlaceholderStyle = placeholderStyle
-
-		if i == m.focusIndex && m.errMsg != "" {
-			m.inputs[i].PromptStyle = errorInputStyle
-			m.inputs[i].TextStyle = errorInputStyle
-		} else if i == m.focusIndex {
-			m.inputs[i].PromptStyle = focusedStyle
-			m.inputs[i].TextStyle = focusedStyle
-		} else if i != m.focusIndex && m.inputs[i].Value() != "" {
-			m.inputs[i].PromptStyle = faintPromptStyle
-		}
+// withPreview shows the preview next to form when there is room, below it
+// otherwise.
+func (m *model) withPreview(form string) string {
+	if width := m.width - lipgloss.Width(form) - 4; width >= minPreviewWidth {
+		return lipgloss.JoinHorizontal(lipgloss.Top, form, " ", m.previewView(width))
 	}
+	return lipgloss.JoinVertical(lipgloss.Left, form, m.previewView(lipgloss.Width(form)-2))
 }
 
-func (m *model) View() string {
-	localFormStyle := formStyle.Width(m.width/2 + 6)
-	if m.done {
-		minLength := 6
-		maxLength := 12
-		if s := strings.TrimSpace(m.inputs[4].Value()); s != "" {
-			if v, err := strconv.Atoi(s); err == nil {
-				minLength = v
+// pageView renders the inputs of the current page.
+func (m *model) pageView() string {
+	pg := m.pages[m.page]
+	focus := m.focused()
+
+	var b strings.Builder
+	b.WriteString(placeholderStyle.Render(pg.spec.description) + "\n")
+	for gi, g := range pg.groups {
+		b.WriteString("\n")
+		for ei, row := range g.entries {
+			if g.spec.repeatable && len(g.spec.fields) > 1 {
+				b.WriteString(faintPromptStyle.Render(fmt.Sprintf("%s %d", capitalize(g.spec.name), ei+1)) + "\n")
 			}
-		}
-		if s := strings.TrimSpace(m.inputs[5].Value()); s != "" {
-			if v, err := strconv.Atoi(s); err == nil {
-				maxLength = v
+			for fi := range row {
+				in := focus.kind == targetInput && focus.group == gi && focus.entry == ei && focus.field == fi
+				setInputStyle(&row[fi], in, in && m.errMsg != "")
+				b.WriteString(row[fi].View() + "\n")
 			}
 		}
-
-		outputPath := "wordlist.txt"
-		if path := strings.TrimSpace(m.inputs[inputOutputFilePath].Value()); path != "" {
-			outputPath = path
+		if g.spec.repeatable {
+			add := fmt.Sprintf("[+ Add another %s]", g.spec.name)
+			b.WriteString(button(add, focus.kind == targetAdd && focus.group == gi) + "\n")
 		}
+	}
 
-		summary := fmt.Sprintf(
-			"Form Submitted!\n\nFirstname: %s\nLastname: %s\nBirthday: %s\nRelated words: %s\nMin password length: %d\nMax password length: %d\nOutput file: %s\nEnable leet variants: %v\nEnable capitalized variants: %v\n\nPress enter to generate password.\nPress b to go back and edit.",
-			m.inputs[0].Value(),
-			m.inputs[1].Value(),
-			m.inputs[2].Value(),
-			m.inputs[3].Value(),
-			minLength,
-			maxLength,
-			outputPath,
-			m.enableLeet,
-			m.enableCapitalize,
-		)
-		return localFormStyle.Render(summary)
+	if pg.spec.transforms {
+		b.WriteString("\n")
+		b.WriteString(button("[ Transforms: "+m.transformsSummary()+" ]", focus.kind == targetTransforms) + "\n")
 	}
-	var b strings.Builder
+	return b.String()
+}
 
-	m.setInputStyles()
+// reviewView renders everything the profile will be generated from.
+func (m *model) reviewView() string {
+	p, _ := m.formProfile()
 
-	for i := range m.inputs {
-		b.WriteString(m.inputs[i].View())
-		b.WriteRune('\n')
+	var b strings.Builder
+	b.WriteString(placeholderStyle.Render("Check the profile before generating.") + "\n\n")
+	line := func(label, value string) {
+		if strings.TrimSpace(value) != "" {
+			fmt.Fprintf(&b, "%s %s\n", faintPromptStyle.Render(label+":"), value)
+		}
 	}
-
-	if m.errMsg != "" {
-		b.WriteString(errorStyle.Render("\n" + m.errMsg + "\n"))
+	line("Name", strings.TrimSpace(p.FirstName+" "+p.LastName))
+	line("Birthday", p.Birthday)
+	var people []string
+	for _, person := range p.People {
+		var details []string
+		for _, d := range []string{person.Relation, person.Birthday} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		if len(details) > 0 {
+			people = append(people, fmt.Sprintf("%s (%s)", person.Name, strings.Join(details, ", ")))
+		} else {
+			people = append(people, person.Name)
+		}
 	}
+	line("People", strings.Join(people, ", "))
+	line("Dates", strings.Join(p.Dates, ", "))
+	line("Phones", strings.Join(p.Phones, ", "))
+	line("Companies", strings.Join(p.Companies, ", "))
+	line("Pets", strings.Join(p.Pets, ", "))
+	line("Places", strings.Join(p.Places, ", "))
+	line("Words", strings.Join(p.Words, ", "))
+	line("Length", fmt.Sprintf("%d to %d", p.MinLength, p.MaxLength))
+	output := p.Output
+	if output == "" {
+		output = "wordlist.txt"
+	}
+	line("Output file", output)
+	line("Transforms", m.transformsSummary())
+	line("Separators", strings.Join(p.Separators, " "))
+	line("Combination depth", fmt.Sprintf("%d words", p.CombineDepth))
+	return b.String()
+}
 
-	seperatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Width(m.width / 2)
-
-	b.WriteString("\n")
-	b.WriteString(seperatorStyle.Render(strings.Repeat("─", m.width/2)))
-	b.WriteString("\n")
-
-	leetChecked := "[ ]"
-	if m.enableLeet {
-		leetChecked = "[X]"
+// buttonsView renders the back and next buttons of the page.
+func (m *model) buttonsView() string {
+	focus := m.focused()
+	next := "[ Next ]"
+	switch {
+	case m.reviewing():
+		next = "[ Generate ]"
+	case m.page == len(m.pages)-1:
+		next = "[ Review ]"
 	}
-	caseChecked := "[ ]"
-	if m.enableCapitalize {
-		caseChecked = "[X]"
+	buttons := button(next, focus.kind == targetNext)
+	if m.page > 0 {
+		buttons = button("[ Back ]", focus.kind == targetBack) + "  " + buttons
 	}
+	return buttons
+}
 
-	leetText := "Enable leet variants"
-	caseText := "Enable capitalized variants"
+func setInputStyle(input *textinput.Model, focused, invalid bool) {
+	input.PromptStyle = lipgloss.NewStyle()
+	input.TextStyle = lipgloss.NewStyle()
+	input.PlaceholderStyle = placeholderStyle
+	switch {
+	case invalid:
+		input.PromptStyle = errorInputStyle
+		input.TextStyle = errorInputStyle
+	case focused:
+		input.PromptStyle = focusedStyle
+		input.TextStyle = focusedStyle
+	case input.Value() != "":
+		input.PromptStyle = faintPromptStyle
+	}
+}
 
-	leetCheckBox := fmt.Sprintf("%s %s", leetChecked, placeholderStyle.Render(leetText))
-	caseCheckBox := fmt.Sprintf("%s %s", caseChecked, placeholderStyle.Render(caseText))
+func button(label string, focused bool) string {
+	if focused {
+		return focusedStyle.Render(label)
+	}
+	return placeholderStyle.Render(label)
+}
 
-	if m.focusIndex == focusLeetBox {
-		leetCheckBox = focusedStyle.Render(leetCheckBox)
+func checkbox(label string, checked, focused bool) string {
+	box := "[ ]"
+	if checked {
+		box = "[X]"
 	}
-	if m.focusIndex == focusCapitalizeBox {
-		caseCheckBox = focusedStyle.Render(caseCheckBox)
+	if focused {
+		return focusedStyle.Render(box + " " + label)
 	}
+	return box + " " + placeholderStyle.Render(label)
+}
 
-	b.WriteString(leetCheckBox + "\n")
-	b.WriteString(caseCheckBox + "\n")
-
-	b.WriteString("\n")
-
-	submitButton := "[ Submit ]"
-
-	if m.focusIndex == focusSubmitButton {
-		submitButton = focusedStyle.Render(submitButton)
-	} else {
-		submitButton = placeholderStyle.Render(submitButton)
+func capitalize(s string) string {
+	if s == "" {
+		return s
 	}
-
-	buttonStyle := lipgloss.NewStyle().Width(m.width / 2)
-	b.WriteString(buttonStyle.Render(submitButton) + "\n")
-
-	help := placeholderStyle.Render(
-		"\n(tab/shift+tab to move, enter to toggle checkboxes or submit, ctrl+r to clear, esc to quit)\n",
-	)
-
-	return localFormStyle.Render(b.String() + help)
+	return strings.ToUpper(s[:1]) + s[1:]
 }
 
 func Start() {
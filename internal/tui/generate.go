@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+)
+
+const (
+	progressBarWidth = 40
+	tickInterval     = 200 * time.Millisecond
+)
+
+var (
+	barFilledStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	barEmptyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	successStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+)
+
+// generation is a run of the generator in the background.
+type generation struct {
+	opts     generator.Options
+	cancel   context.CancelFunc
+	updates  chan generator.Progress
+	progress generator.Progress
+	started  time.Time
+	elapsed  time.Duration
+	// cancelled is set once the user asked to stop.
+	cancelled bool
+}
+
+// generationResult is what a finished run produced.
+type generationResult struct {
+	path     string
+	progress generator.Progress
+	elapsed  time.Duration
+	size     int64
+	sizeErr  error
+}
+
+type (
+	progressMsg  generator.Progress
+	tickMsg      time.Time
+	generatedMsg struct {
+		progress generator.Progress
+		err      error
+	}
+)
+
+// startGeneration runs the generator for opts in the background and returns
+// the commands that report on it.
+func (m *model) startGeneration(opts generator.Options) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &generation{
+		opts:    opts,
+		cancel:  cancel,
+		updates: make(chan generator.Progress, 1),
+		started: time.Now(),
+	}
+	m.generation = g
+
+	run := func() tea.Msg {
+		var last generator.Progress
+		err := generator.Run(ctx, opts, func(p generator.Progress) {
+			last = p
+			// Drop reports the view has not caught up with yet.
+			select {
+			case g.updates <- p:
+			default:
+			}
+		})
+		close(g.updates)
+		cancel()
+		return generatedMsg{progress: last, err: err}
+	}
+	return tea.Batch(run, waitForProgress(g.updates), tick())
+}
+
+func waitForProgress(updates <-chan generator.Progress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return progressMsg(p)
+	}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// updateGeneration handles the messages of a running generation.
+func (m *model) updateGeneration(msg tea.Msg) (tea.Model, tea.Cmd) {
+	g := m.generation
+	switch msg := msg.(type) {
+	case progressMsg:
+		g.progress = generator.Progress(msg)
+		g.elapsed = time.Since(g.started)
+		return m, waitForProgress(g.updates)
+	case tickMsg:
+		g.elapsed = time.Since(g.started)
+		return m, tick()
+	case generatedMsg:
+		m.generation = nil
+		switch {
+		case errors.Is(msg.err, context.Canceled):
+			m.errMsg = fmt.Sprintf("generation cancelled, the partial wordlist was left at %s", generator.OutputPath(g.opts))
+			return m, nil
+		case msg.err != nil:
+			m.errMsg = fmt.Sprintf("could not generate password: %v", msg.err)
+			return m, nil
+		}
+		path := generator.OutputPath(g.opts)
+		result := &generationResult{
+			path:     path,
+			progress: msg.progress,
+			elapsed:  time.Since(g.started),
+		}
+		if info, err := os.Stat(path); err != nil {
+			result.sizeErr = err
+		} else {
+			result.size = info.Size()
+		}
+		m.result = result
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "c", "q":
+			g.cancel()
+			g.cancelled = true
+		}
+	}
+	return m, nil
+}
+
+// updateResult handles the keys of the results screen.
+func (m *model) updateResult(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "v":
+		return m, m.openViewer(m.result.path)
+	case "b", "backspace":
+		m.result = nil
+		return m, m.showPage(0)
+	}
+	return m, nil
+}
+
+func (m *model) generationView() string {
+	g := m.generation
+	p := g.progress
+
+	var b strings.Builder
+	title := "Generating wordlist..."
+	if g.cancelled {
+		title = "Cancelling..."
+	}
+	b.WriteString(focusedStyle.Render(title) + "\n\n")
+
+	filled := int(p.Fraction() * progressBarWidth)
+	b.WriteString(barFilledStyle.Render(strings.Repeat("█", filled)))
+	b.WriteString(barEmptyStyle.Render(strings.Repeat("░", progressBarWidth-filled)))
+	fmt.Fprintf(&b, " %3.0f%%\n\n", p.Fraction()*100)
+
+	eta := "--"
+	if p.JobsDone > 0 {
+		eta = p.ETA().Round(time.Second).String()
+	}
+	fmt.Fprintf(&b, "Produced: %d\n", p.Produced)
+	fmt.Fprintf(&b, "Written:  %d\n", p.Written)
+	fmt.Fprintf(&b, "Dropped:  %d\n", p.Dropped)
+	fmt.Fprintf(&b, "Rate:     %.0f/s\n", p.Rate())
+	fmt.Fprintf(&b, "Elapsed:  %s\n", g.elapsed.Round(time.Second))
+	fmt.Fprintf(&b, "ETA:      %s\n", eta)
+
+	b.WriteString(placeholderStyle.Render("\n(esc or c to cancel)\n"))
+	return b.String()
+}
+
+func (m *model) resultView() string {
+	r := m.result
+
+	var b strings.Builder
+	b.WriteString(successStyle.Render("Wordlist generated!") + "\n\n")
+	fmt.Fprintf(&b, "File:      %s\n", r.path)
+	fmt.Fprintf(&b, "Passwords: %d\n", r.progress.Written)
+	if r.sizeErr != nil {
+		fmt.Fprintf(&b, "Size:      unknown (%v)\n", r.sizeErr)
+	} else {
+		fmt.Fprintf(&b, "Size:      %s\n", generator.FormatBytes(r.size))
+	}
+	fmt.Fprintf(&b, "Dropped:   %d\n", r.progress.Dropped)
+	fmt.Fprintf(&b, "Took:      %s\n", r.elapsed.Round(time.Millisecond))
+
+	b.WriteString(placeholderStyle.Render("\n(enter to quit, v to view the wordlist, b to go back and edit)\n"))
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+)
+
+const (
+	// previewSize is the number of first and of random passwords shown.
+	previewSize = 50
+	// previewDelay is how long typing has to pause before the preview
+	// is updated.
+	previewDelay = 300 * time.Millisecond
+	// minPreviewWidth is the narrowest the preview is shown next to the
+	// form rather than below it.
+	minPreviewWidth = 30
+)
+
+var previewStyle = lipgloss.NewStyle().
+	Padding(1, 2).
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+// preview is a sample of what the form would generate.
+type preview struct {
+	// key identifies the profile the preview is for.
+	key    string
+	seq    int
+	cancel context.CancelFunc
+	// loading is set from the change of the form until its sample is in.
+	loading bool
+	sample  generator.Preview
+	err     error
+}
+
+type (
+	previewTickMsg struct{ seq int }
+	previewMsg     struct {
+		seq    int
+		sample generator.Preview
+		err    error
+	}
+)
+
+// schedulePreview updates the preview once the form stops changing for
+// previewDelay.
+func (m *model) schedulePreview() tea.Cmd {
+	p, err := m.profile()
+	// The output path does not change what is generated.
+	p.Output = ""
+	key := fmt.Sprintf("%+v %v", p, err)
+	if key == m.preview.key {
+		return nil
+	}
+	m.preview.key = key
+	m.preview.seq++
+	if m.preview.cancel != nil {
+		m.preview.cancel()
+		m.preview.cancel = nil
+	}
+	if err != nil {
+		m.preview.loading = false
+		m.preview.sample = generator.Preview{}
+		m.preview.err = err
+		return nil
+	}
+
+	m.preview.loading = true
+	m.preview.err = nil
+	seq := m.preview.seq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq}
+	})
+}
+
+// updatePreview handles the messages of the preview.
+func (m *model) updatePreview(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case previewTickMsg:
+		if msg.seq != m.preview.seq {
+			return nil
+		}
+		p, err := m.profile()
+		if err != nil {
+			return nil
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		m.preview.cancel = cancel
+		opts := p.Options()
+		return func() tea.Msg {
+			sample, err := generator.Sample(ctx, opts, previewSize)
+			return previewMsg{seq: msg.seq, sample: sample, err: err}
+		}
+	case previewMsg:
+		if msg.seq != m.preview.seq {
+			return nil
+		}
+		m.preview.loading = false
+		m.preview.cancel = nil
+		m.preview.sample, m.preview.err = msg.sample, msg.err
+	}
+	return nil
+}
+
+func (m *model) previewView(width int) string {
+	pv := m.preview
+	var b strings.Builder
+
+	title := "Preview"
+	if pv.loading {
+		title += placeholderStyle.Render(" (updating...)")
+	}
+	b.WriteString(focusedStyle.Render(title) + "\n\n")
+
+	if pv.err != nil {
+		b.WriteString(placeholderStyle.Render(pv.err.Error()))
+		return previewStyle.Width(width).Render(b.String())
+	}
+	if pv.sample.Total == 0 {
+		if !pv.loading {
+			b.WriteString(placeholderStyle.Render("nothing to generate"))
+		}
+		return previewStyle.Width(width).Render(b.String())
+	}
+
+	total := fmt.Sprintf("%d passwords", pv.sample.Total)
+	if !pv.sample.Exact {
+		total = "about " + total
+	}
+	b.WriteString(total + "\n")
+
+	words := lipgloss.NewStyle().Width(width - 4)
+	if len(pv.sample.First) > 0 {
+		b.WriteString(placeholderStyle.Render(fmt.Sprintf("\nFirst %d:", len(pv.sample.First))) + "\n")
+		b.WriteString(words.Render(strings.Join(pv.sample.First, " ")) + "\n")
+	}
+	if len(pv.sample.Random) > 0 {
+		b.WriteString(placeholderStyle.Render(fmt.Sprintf("\nRandom %d:", len(pv.sample.Random))) + "\n")
+		b.WriteString(words.Render(strings.Join(pv.sample.Random, " ")))
+	}
+	return previewStyle.Width(width).Render(b.String())
+}
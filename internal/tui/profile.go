@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
+)
+
+const defaultProfilePath = "profile.json"
+
+// pickerHeight is the number of files the file picker lists at once.
+const pickerHeight = 15
+
+// openPicker shows the file picker to load a profile from, starting in the
+// directory of the last profile.
+func (m *model) openPicker() tea.Cmd {
+	m.picker = filepicker.New()
+	m.picker.AllowedTypes = []string{".json"}
+	m.picker.SetHeight(pickerHeight)
+	m.picker.CurrentDirectory = "."
+	if m.profilePath != "" {
+		m.picker.CurrentDirectory = filepath.Dir(m.profilePath)
+	}
+	m.picking = true
+	m.statusMsg, m.errMsg = "", ""
+	return m.picker.Init()
+}
+
+// updatePicker handles the messages while the file picker is shown.
+func (m *model) updatePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc", "ctrl+c":
+			m.picking = false
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.picker, cmd = m.picker.Update(msg)
+	if ok, path := m.picker.DidSelectFile(msg); ok {
+		m.picking = false
+		return m, tea.Batch(cmd, m.loadProfile(path))
+	}
+	return m, cmd
+}
+
+// loadProfile fills the wizard with the profile at path.
+func (m *model) loadProfile(path string) tea.Cmd {
+	p, err := profile.LoadFile(path)
+	if err != nil {
+		m.errMsg = fmt.Sprintf("could not load profile: %v", err)
+		return nil
+	}
+
+	m.setProfile(p)
+	m.profilePath = path
+	focus := m.showPage(0)
+	m.statusMsg = fmt.Sprintf("Profile loaded from %s", path)
+	if err := p.Validate(); err != nil {
+		m.errMsg = err.Error()
+	}
+	return tea.Batch(focus, m.schedulePreview())
+}
+
+// lengthValue returns the text of a length input, empty for the default.
+func lengthValue(length, defaultLength int) string {
+	if length == defaultLength {
+		return ""
+	}
+	return strconv.Itoa(length)
+}
+
+// openSaveDialog asks for the path to save the form to.
+func (m *model) openSaveDialog() tea.Cmd {
+	path := m.profilePath
+	if path == "" {
+		path = defaultProfilePath
+	}
+	m.saveInput = textinput.New()
+	m.saveInput.Prompt = "Save profile to: "
+	m.saveInput.PromptStyle = focusedStyle
+	m.saveInput.SetValue(path)
+	m.saving = true
+	m.overwrite = ""
+	m.statusMsg, m.errMsg = "", ""
+	return m.saveInput.Focus()
+}
+
+// updateSaveDialog handles the messages while the save dialog is shown.
+func (m *model) updateSaveDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc", "ctrl+c":
+			m.saving = false
+			return m, nil
+		case "enter":
+			path := strings.TrimSpace(m.saveInput.Value())
+			// Like profile init without --force, an existing file is only
+			// replaced once confirmed, unless it is the one being edited.
+			if path != m.overwrite && path != m.profilePath && fileExists(path) {
+				m.overwrite = path
+				return m, nil
+			}
+			m.saving = false
+			m.saveProfile(path)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.saveInput, cmd = m.saveInput.Update(msg)
+	if strings.TrimSpace(m.saveInput.Value()) != m.overwrite {
+		m.overwrite = ""
+	}
+	return m, cmd
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// saveProfile saves the form, valid or not, to a profile file at path.
+func (m *model) saveProfile(path string) {
+	if path == "" {
+		m.errMsg = "profile path cannot be empty"
+		return
+	}
+	// The profile is saved even if it is not valid yet, as long as every
+	// input can be stored.
+	p, err := m.formProfile()
+	if err != nil {
+		m.errMsg = err.Error()
+		return
+	}
+	if err := p.SaveFile(path); err != nil {
+		m.errMsg = fmt.Sprintf("could not save profile: %v", err)
+		return
+	}
+	m.profilePath = path
+	m.statusMsg = fmt.Sprintf("Profile saved at %s", path)
+}
+
+func (m *model) pickerView() string {
+	var b strings.Builder
+	b.WriteString(focusedStyle.Render("Load profile") + "\n")
+	dir, err := filepath.Abs(m.picker.CurrentDirectory)
+	if err != nil {
+		dir = m.picker.CurrentDirectory
+	}
+	b.WriteString(placeholderStyle.Render(dir) + "\n\n")
+	b.WriteString(m.picker.View())
+	b.WriteString(placeholderStyle.Render("\n(up/down to move, enter to open, left/backspace to go up, esc to cancel)\n"))
+	return b.String()
+}
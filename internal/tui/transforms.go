@@ -0,0 +1,324 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
+)
+
+// argsPlaceholders describe the arguments of the configurable transforms.
+var argsPlaceholders = map[string]string{
+	"leet":   "table, e.g. a=@,s=$ (default a=4,e=3,i=1,o=0,s=5)",
+	"suffix": "suffixes, e.g. !,2024 (default 1,12,123,!,1!,123!)",
+}
+
+// transformItem is a transform of the panel, applied when enabled.
+type transformItem struct {
+	name        string
+	description string
+	enabled     bool
+	// args holds the arguments of a configurable transform, nil for the
+	// others.
+	args *textinput.Model
+}
+
+func (item *transformItem) spec() string {
+	if item.args == nil || strings.TrimSpace(item.args.Value()) == "" {
+		return item.name
+	}
+	return item.name + ":" + strings.TrimSpace(item.args.Value())
+}
+
+// transformPanel configures the transforms, in the order they are applied,
+// and how words are combined.
+type transformPanel struct {
+	items      []*transformItem
+	separators textinput.Model
+	depth      int
+	// focus is the row in focus: an item, then the separators and the
+	// depth.
+	focus int
+}
+
+func (tp *transformPanel) separatorsRow() int { return len(tp.items) }
+func (tp *transformPanel) depthRow() int      { return len(tp.items) + 1 }
+
+// specs returns the enabled transforms in order, as in profile files.
+func (tp *transformPanel) specs() []string {
+	specs := []string{}
+	for _, item := range tp.items {
+		if item.enabled {
+			specs = append(specs, item.spec())
+		}
+	}
+	return specs
+}
+
+func (tp *transformPanel) separatorValues() []string {
+	separators := []string{}
+	for _, sep := range strings.Split(tp.separators.Value(), ",") {
+		if sep = strings.TrimSpace(sep); sep != "" {
+			separators = append(separators, sep)
+		}
+	}
+	return separators
+}
+
+// focusedItem returns the item in focus, if any.
+func (tp *transformPanel) focusedItem() *transformItem {
+	if tp.focus < len(tp.items) {
+		return tp.items[tp.focus]
+	}
+	return nil
+}
+
+// setTransforms fills the panel with the transforms of p, the leet and caps
+// options of older profiles included, followed by the transforms it does
+// not use.
+func (m *model) setTransforms(p profile.Profile) {
+	specs := slices.Clone(p.Transforms)
+	listed := func(name string) bool {
+		return slices.ContainsFunc(specs, func(spec string) bool {
+			listedName, _, _ := strings.Cut(spec, ":")
+			return listedName == name
+		})
+	}
+	if p.Leet && !listed("leet") {
+		specs = append(specs, "leet")
+	}
+	if p.Caps && !listed("swapcase") {
+		specs = append(specs, "swapcase")
+	}
+
+	tp := transformPanel{depth: p.CombineDepth}
+	if tp.depth == 0 {
+		tp.depth = profile.DefaultCombineDepth
+	}
+	add := func(name, args string, enabled bool) {
+		item := &transformItem{name: name, enabled: enabled}
+		t, ok := generator.LookupTransform(name)
+		if ok {
+			item.description = t.Description()
+		}
+		if _, configurable := t.(generator.ConfigurableTransform); configurable || args != "" {
+			placeholder, ok := argsPlaceholders[name]
+			if !ok {
+				placeholder = "arguments (optional)"
+			}
+			args := m.newInput(placeholder, args)
+			args.Prompt = "    "
+			item.args = &args
+		}
+		tp.items = append(tp.items, item)
+	}
+	for _, spec := range specs {
+		name, args, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		add(name, args, true)
+	}
+	for _, t := range generator.Transforms() {
+		if !listed(t.Name()) {
+			add(t.Name(), "", false)
+		}
+	}
+
+	tp.separators = m.newInput("e.g. _,. to also make john_doe and john.doe", strings.Join(p.Separators, ","))
+	tp.separators.Prompt = "Separators: "
+	m.transforms = tp
+}
+
+// transformsError validates the settings of the panel.
+func (m *model) transformsError() error {
+	p, err := m.formProfile()
+	if err != nil {
+		return err
+	}
+	for _, field := range []string{profile.FieldTransforms, profile.FieldSeparators, profile.FieldCombineDepth} {
+		if err := p.ValidateField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openTransforms shows the panel with row in focus.
+func (m *model) openTransforms(row int) tea.Cmd {
+	m.configuring = true
+	m.errMsg, m.statusMsg = "", ""
+	return m.focusTransformRow(row)
+}
+
+func (m *model) focusTransformRow(row int) tea.Cmd {
+	tp := &m.transforms
+	n := tp.depthRow() + 1
+	tp.focus = (row%n + n) % n
+	for _, item := range tp.items {
+		if item.args != nil {
+			item.args.Blur()
+		}
+	}
+	tp.separators.Blur()
+	if item := tp.focusedItem(); item != nil && item.args != nil {
+		return item.args.Focus()
+	}
+	if tp.focus == tp.separatorsRow() {
+		return tp.separators.Focus()
+	}
+	return nil
+}
+
+// transformRowOf returns the row of the panel an error is about.
+func (m *model) transformRowOf(err *profile.FieldError) int {
+	tp := &m.transforms
+	switch err.Field {
+	case profile.FieldSeparators:
+		return tp.separatorsRow()
+	case profile.FieldCombineDepth:
+		return tp.depthRow()
+	}
+	// Transforms are indexed among the enabled ones.
+	index := err.Index
+	for row, item := range tp.items {
+		if !item.enabled {
+			continue
+		}
+		if index == 0 {
+			return row
+		}
+		index--
+	}
+	return 0
+}
+
+// updateTransforms handles the messages while the panel is shown.
+func (m *model) updateTransforms(msg tea.Msg) (tea.Model, tea.Cmd) {
+	tp := &m.transforms
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "ctrl+t":
+			if err := m.transformsError(); err != nil {
+				var fieldErr *profile.FieldError
+				if errors.As(err, &fieldErr) {
+					return m, m.focusTransformRow(m.transformRowOf(fieldErr))
+				}
+				return m, nil
+			}
+			m.configuring = false
+			return m, m.setFocus(m.focusIndex)
+		case "tab", "down":
+			return m, m.focusTransformRow(tp.focus + 1)
+		case "shift+tab", "up":
+			return m, m.focusTransformRow(tp.focus - 1)
+		case "shift+up", "alt+up":
+			return m, tea.Batch(m.moveTransform(-1), m.schedulePreview())
+		case "shift+down", "alt+down":
+			return m, tea.Batch(m.moveTransform(1), m.schedulePreview())
+		case " ", "enter":
+			if item := tp.focusedItem(); item != nil {
+				item.enabled = !item.enabled
+				return m, m.schedulePreview()
+			}
+		case "left", "-":
+			if tp.focus == tp.depthRow() {
+				tp.depth = max(tp.depth-1, 1)
+				return m, m.schedulePreview()
+			}
+		case "right", "+":
+			if tp.focus == tp.depthRow() {
+				tp.depth = min(tp.depth+1, generator.MaxCombineDepth)
+				return m, m.schedulePreview()
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	switch {
+	case tp.focusedItem() != nil && tp.focusedItem().args != nil:
+		*tp.focusedItem().args, cmd = tp.focusedItem().args.Update(msg)
+	case tp.focus == tp.separatorsRow():
+		tp.separators, cmd = tp.separators.Update(msg)
+	}
+	return m, tea.Batch(cmd, m.schedulePreview())
+}
+
+// moveTransform moves the transform in focus up or down the order.
+func (m *model) moveTransform(by int) tea.Cmd {
+	tp := &m.transforms
+	to := tp.focus + by
+	if tp.focusedItem() == nil || to < 0 || to >= len(tp.items) {
+		return nil
+	}
+	tp.items[tp.focus], tp.items[to] = tp.items[to], tp.items[tp.focus]
+	return m.focusTransformRow(to)
+}
+
+// transformsSummary lists the enabled transforms in order.
+func (m *model) transformsSummary() string {
+	specs := m.transforms.specs()
+	if len(specs) == 0 {
+		return "none"
+	}
+	return strings.Join(specs, " → ")
+}
+
+func (m *model) transformsView() string {
+	tp := &m.transforms
+	var b strings.Builder
+	b.WriteString(focusedStyle.Render("Transforms") + "\n")
+	b.WriteString(placeholderStyle.Render("Applied from top to bottom to every word and combination, each to the variants so far.") + "\n\n")
+
+	nameWidth := 0
+	for _, item := range tp.items {
+		nameWidth = max(nameWidth, len(item.name))
+	}
+	for row, item := range tp.items {
+		label := fmt.Sprintf("%-*s  %s", nameWidth, item.name, placeholderStyle.Render(item.description))
+		b.WriteString(checkbox(label, item.enabled, row == tp.focus) + "\n")
+		if item.args != nil && (item.enabled || row == tp.focus) {
+			setInputStyle(item.args, row == tp.focus, false)
+			b.WriteString(item.args.View() + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	setInputStyle(&tp.separators, tp.focus == tp.separatorsRow(), false)
+	b.WriteString(tp.separators.View() + "\n")
+	depth := fmt.Sprintf("Combination depth: < %d > words", tp.depth)
+	if tp.focus == tp.depthRow() {
+		b.WriteString(focusedStyle.Render(depth) + "\n")
+	} else {
+		b.WriteString(depth + "\n")
+	}
+
+	b.WriteString("\n" + m.estimateView() + "\n")
+	if err := m.transformsError(); err != nil {
+		b.WriteString(errorStyle.Render("\n"+err.Error()) + "\n")
+	}
+	b.WriteString(placeholderStyle.Render("\n(tab/shift+tab to move, space to toggle, shift+up/shift+down to reorder, left/right to change the depth, esc when done)\n"))
+	return b.String()
+}
+
+// estimateView renders the size the wordlist is expected to have, as last
+// sampled by the preview.
+func (m *model) estimateView() string {
+	pv := m.preview
+	switch {
+	case pv.loading:
+		return placeholderStyle.Render("Estimating the wordlist size...")
+	case pv.err != nil:
+		return placeholderStyle.Render("No estimate: " + pv.err.Error())
+	case pv.sample.Total == 0:
+		return "Wordlist size: nothing to generate"
+	case pv.sample.Exact:
+		return fmt.Sprintf("Wordlist size: %d passwords", pv.sample.Total)
+	}
+	return fmt.Sprintf("Wordlist size: about %d passwords", pv.sample.Total)
+}
@@ -0,0 +1,416 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/stats"
+)
+
+const (
+	// viewerMaxWords is the most words the viewer loads, bigger wordlists
+	// are better left to a pager.
+	viewerMaxWords = 1000000
+	// filterDelay is how long typing has to pause before the filter is
+	// applied.
+	filterDelay = 150 * time.Millisecond
+	// histogramWidth is the width of the longest bar of the stats.
+	histogramWidth = 20
+)
+
+var removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
+
+// viewer browses a generated wordlist and removes words from it.
+type viewer struct {
+	path    string
+	loading bool
+	words   []string
+	// lines are the lines of the words in the wordlist, which hold more
+	// than the word in structured formats.
+	lines   []string
+	removed []bool
+	// matches are the indexes of the words shown, every word unless
+	// filtered.
+	matches   []int
+	filter    textinput.Model
+	filtering bool
+	// filterSeq identifies the last change of the filter.
+	filterSeq int
+	// cursor is the match in focus and offset the first match shown.
+	cursor int
+	offset int
+	// stats describe the words not removed.
+	stats stats.Stats
+	// unsaved counts the words removed since the last save, and quitting
+	// is set once asked to leave with some.
+	unsaved  int
+	quitting bool
+	status   string
+	err      error
+}
+
+type (
+	viewerLoadedMsg struct {
+		words []string
+		lines []string
+		err   error
+	}
+	filterTickMsg struct{ seq int }
+)
+
+// openViewer loads the wordlist at path into the viewer.
+func (m *model) openViewer(path string) tea.Cmd {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter"
+	filter.PromptStyle = focusedStyle
+	filter.PlaceholderStyle = placeholderStyle
+	m.viewer = &viewer{path: path, loading: true, filter: filter}
+	return func() tea.Msg {
+		words, lines, err := loadWords(path)
+		return viewerLoadedMsg{words: words, lines: lines, err: err}
+	}
+}
+
+// loadWords reads the words of the wordlist at path, in the format its
+// extension names, and the lines they were read from.
+func loadWords(path string) ([]string, []string, error) {
+	r, err := generator.OpenWordlist(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	words, lines := []string{}, []string{}
+	err = generator.ScanPasswords(r, generator.WordlistFormat(path), func(word, line string) error {
+		if len(words) == viewerMaxWords {
+			return fmt.Errorf("more than %d words, too many to view here", viewerMaxWords)
+		}
+		words = append(words, word)
+		lines = append(lines, line)
+		return nil
+	})
+	return words, lines, err
+}
+
+// pageSize is the number of words shown at once.
+func (m *model) pageSize() int {
+	if m.height == 0 {
+		return 20
+	}
+	return max(m.height-14, 5)
+}
+
+// refilter lists the words matching the filter, case insensitively.
+func (v *viewer) refilter() {
+	query := strings.ToLower(v.filter.Value())
+	v.matches = v.matches[:0]
+	for i, word := range v.words {
+		if query == "" || strings.Contains(strings.ToLower(word), query) {
+			v.matches = append(v.matches, i)
+		}
+	}
+	v.cursor, v.offset = 0, 0
+}
+
+// scheduleFilter applies the filter once it stops changing for
+// filterDelay.
+func (v *viewer) scheduleFilter() tea.Cmd {
+	v.filterSeq++
+	seq := v.filterSeq
+	return tea.Tick(filterDelay, func(time.Time) tea.Msg {
+		return filterTickMsg{seq: seq}
+	})
+}
+
+// recount collects the stats of the words not removed.
+func (v *viewer) recount() {
+	v.stats = stats.Stats{
+		Lengths:  make(map[int]int64),
+		Charsets: make(map[string]int64),
+	}
+	for i, word := range v.words {
+		if !v.removed[i] {
+			v.stats.Add(word)
+		}
+	}
+}
+
+// toggle removes the words at indexes, or brings them back if they are all
+// removed already.
+func (v *viewer) toggle(indexes []int) {
+	remove := slices.ContainsFunc(indexes, func(i int) bool { return !v.removed[i] })
+	for _, i := range indexes {
+		if v.removed[i] != remove {
+			v.removed[i] = remove
+			if remove {
+				v.unsaved++
+				v.stats.Remove(v.words[i])
+			} else {
+				v.unsaved--
+				v.stats.Add(v.words[i])
+			}
+		}
+	}
+	v.quitting = false
+}
+
+// move moves the cursor by n matches, scrolling to keep it shown.
+func (v *viewer) move(n, pageSize int) {
+	v.cursor = max(min(v.cursor+n, len(v.matches)-1), 0)
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+pageSize {
+		v.offset = v.cursor - pageSize + 1
+	}
+}
+
+// save writes the words not removed back to the wordlist.
+func (m *model) saveViewer() {
+	v := m.viewer
+	kept := make([]string, 0, len(v.words))
+	keptLines := make([]string, 0, len(v.lines))
+	for i, word := range v.words {
+		if !v.removed[i] {
+			kept = append(kept, word)
+			keptLines = append(keptLines, v.lines[i])
+		}
+	}
+	if err := generator.WriteWordlist(v.path, keptLines); err != nil {
+		v.status = ""
+		v.err = fmt.Errorf("could not save the wordlist: %w", err)
+		return
+	}
+
+	removed := len(v.words) - len(kept)
+	v.words, v.lines = kept, keptLines
+	v.removed = make([]bool, len(kept))
+	v.unsaved, v.quitting, v.err = 0, false, nil
+	v.refilter()
+	v.status = fmt.Sprintf("Saved %d words to %s, %d removed", len(kept), v.path, removed)
+
+	if r := m.result; r != nil && r.path == v.path {
+		r.progress.Written = int64(len(kept))
+		if info, err := os.Stat(v.path); err == nil {
+			r.size, r.sizeErr = info.Size(), nil
+		}
+	}
+}
+
+// updateViewer handles the messages while the viewer is shown.
+func (m *model) updateViewer(msg tea.Msg) (tea.Model, tea.Cmd) {
+	v := m.viewer
+	switch msg := msg.(type) {
+	case viewerLoadedMsg:
+		v.loading = false
+		if msg.err != nil {
+			v.err = msg.err
+			return m, nil
+		}
+		v.words, v.lines = msg.words, msg.lines
+		v.removed = make([]bool, len(v.words))
+		v.refilter()
+		v.recount()
+		return m, nil
+	case filterTickMsg:
+		if msg.seq == v.filterSeq {
+			v.refilter()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if v.filtering {
+			switch msg.String() {
+			case "enter":
+				v.filtering = false
+				v.filter.Blur()
+				v.filterSeq++
+				v.refilter()
+				return m, nil
+			case "esc":
+				v.filtering = false
+				v.filter.Blur()
+				v.filter.SetValue("")
+				v.filterSeq++
+				v.refilter()
+				return m, nil
+			}
+			value := v.filter.Value()
+			var cmd tea.Cmd
+			v.filter, cmd = v.filter.Update(msg)
+			if v.filter.Value() != value {
+				cmd = tea.Batch(cmd, v.scheduleFilter())
+			}
+			return m, cmd
+		}
+		return m, m.viewerKey(msg)
+	}
+	if v.filtering {
+		var cmd tea.Cmd
+		v.filter, cmd = v.filter.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *model) viewerKey(msg tea.KeyMsg) tea.Cmd {
+	v := m.viewer
+	page := m.pageSize()
+	switch msg.String() {
+	case "esc", "q":
+		if v.filter.Value() != "" && msg.String() == "esc" {
+			v.filter.SetValue("")
+			v.filterSeq++
+			v.refilter()
+			return nil
+		}
+		if v.unsaved > 0 && !v.quitting {
+			v.quitting = true
+			v.status = fmt.Sprintf("%d removed words are not saved: s to save them, q again to leave anyway", v.unsaved)
+			return nil
+		}
+		m.viewer = nil
+		return nil
+	}
+	if v.loading || (v.err != nil && v.words == nil) {
+		return nil
+	}
+
+	v.status = ""
+	switch msg.String() {
+	case "up", "k":
+		v.move(-1, page)
+	case "down", "j":
+		v.move(1, page)
+	case "pgup", "left", "h":
+		v.move(-page, page)
+	case "pgdown", "right", "l", " ":
+		v.move(page, page)
+	case "home", "g":
+		v.move(-len(v.matches), page)
+	case "end", "G":
+		v.move(len(v.matches), page)
+	case "/":
+		v.filtering = true
+		return v.filter.Focus()
+	case "d", "x", "delete":
+		if len(v.matches) > 0 {
+			v.toggle(v.matches[v.cursor : v.cursor+1])
+			v.move(1, page)
+		}
+	case "D":
+		v.toggle(v.matches)
+	case "s":
+		m.saveViewer()
+	}
+	return nil
+}
+
+func (m *model) viewerView() string {
+	v := m.viewer
+	var b strings.Builder
+	b.WriteString(focusedStyle.Render("Wordlist") + placeholderStyle.Render("  "+v.path) + "\n\n")
+	if v.loading {
+		b.WriteString(placeholderStyle.Render("Loading...") + "\n")
+		return formStyle.Width(m.width/2 + 6).Render(b.String())
+	}
+	if v.words == nil && v.err != nil {
+		b.WriteString(errorStyle.Render(v.err.Error()) + "\n")
+		b.WriteString(placeholderStyle.Render("\n(esc to go back)\n"))
+		return formStyle.Width(m.width/2 + 6).Render(b.String())
+	}
+
+	page := m.pageSize()
+	end := min(v.offset+page, len(v.matches))
+	for i := v.offset; i < end; i++ {
+		word := v.words[v.matches[i]]
+		switch {
+		case v.removed[v.matches[i]]:
+			word = removedStyle.Render(word)
+		case i == v.cursor:
+			word = focusedStyle.Render(word)
+		}
+		cursor := "  "
+		if i == v.cursor {
+			cursor = focusedStyle.Render("> ")
+		}
+		b.WriteString(cursor + word + "\n")
+	}
+	for i := end - v.offset; i < page; i++ {
+		b.WriteString("\n")
+	}
+
+	position := fmt.Sprintf("%d words", len(v.words))
+	if len(v.matches) > 0 {
+		position = fmt.Sprintf("%d of %d", v.cursor+1, len(v.matches))
+		if len(v.matches) != len(v.words) {
+			position += fmt.Sprintf(" matches, %d words", len(v.words))
+		}
+	}
+	b.WriteString("\n" + placeholderStyle.Render(position) + "\n")
+	if v.filtering || v.filter.Value() != "" {
+		b.WriteString(v.filter.View() + "\n")
+	}
+
+	if v.err != nil {
+		b.WriteString(errorStyle.Render("\n"+v.err.Error()) + "\n")
+	}
+	if v.status != "" {
+		b.WriteString(successStyle.Render("\n"+v.status) + "\n")
+	}
+	help := "(up/down to move, left/right for pages, / to filter, d to remove or restore, D for every match, s to save, q to go back)"
+	if v.filtering {
+		help = "(enter to keep the filter, esc to clear it)"
+	}
+	b.WriteString(placeholderStyle.Render("\n" + help + "\n"))
+
+	list := formStyle.Width(m.width/2 + 6).Render(b.String())
+	if width := m.width - lipgloss.Width(list) - 4; width >= minPreviewWidth {
+		return lipgloss.JoinHorizontal(lipgloss.Top, list, " ", m.statsView(width))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, list, m.statsView(lipgloss.Width(list)-2))
+}
+
+// statsView renders the length histogram and charset classes of the words
+// not removed.
+func (m *model) statsView(width int) string {
+	s := m.viewer.stats
+	var b strings.Builder
+	b.WriteString(focusedStyle.Render("Stats") + "\n\n")
+	fmt.Fprintf(&b, "Words:   %d", s.Words)
+	if removed := len(m.viewer.words) - int(s.Words); removed > 0 {
+		b.WriteString(placeholderStyle.Render(fmt.Sprintf(" (%d removed)", removed)))
+	}
+	b.WriteString("\n")
+	if s.Words == 0 {
+		return previewStyle.Width(width).Render(b.String())
+	}
+	fmt.Fprintf(&b, "Length:  %d to %d, %.1f on average\n", s.MinLength, s.MaxLength, s.AverageLength())
+
+	var most int64
+	for _, words := range s.Lengths {
+		most = max(most, words)
+	}
+	b.WriteString(placeholderStyle.Render("\nLengths") + "\n")
+	for length := s.MinLength; length <= s.MaxLength; length++ {
+		words := s.Lengths[length]
+		bar := strings.Repeat("█", int(words*histogramWidth/most))
+		fmt.Fprintf(&b, "%3d %-*s %d\n", length, histogramWidth, bar, words)
+	}
+
+	b.WriteString(placeholderStyle.Render("\nCharsets") + "\n")
+	for _, c := range s.SortedCharsets() {
+		fmt.Fprintf(&b, "%5.1f%%  %s\n", float64(c.Words)*100/float64(s.Words), c.Value)
+	}
+	return previewStyle.Width(width).Render(b.String())
+}
@@ -0,0 +1,521 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/profile"
+)
+
+// fieldSpec is a text input of the wizard.
+type fieldSpec struct {
+	placeholder string
+	// field is the profile field the input is validated as, and part the
+	// part of its entries the input is, as told by profile.FieldError.
+	field string
+	part  string
+}
+
+// groupSpec is a set of inputs filled in together, once or, when
+// repeatable, as many times as needed (one pet per entry, say).
+type groupSpec struct {
+	// name is what one entry is, e.g. "pet".
+	name       string
+	repeatable bool
+	fields     []fieldSpec
+	// get returns the entries of the group in a profile and set stores
+	// them; set is only given the non-empty entries of a repeatable group.
+	get func(p profile.Profile) [][]string
+	set func(p *profile.Profile, entries [][]string) error
+}
+
+// pageSpec is a step of the wizard.
+type pageSpec struct {
+	title       string
+	description string
+	groups      []groupSpec
+	// transforms adds the button of the transform panel.
+	transforms bool
+}
+
+// listGroup is a repeatable group of single values stored in the list of
+// a profile field.
+func listGroup(name, placeholder, field string, list func(p *profile.Profile) *[]string) groupSpec {
+	return groupSpec{
+		name:       name,
+		repeatable: true,
+		fields:     []fieldSpec{{placeholder: placeholder, field: field}},
+		get: func(p profile.Profile) [][]string {
+			var entries [][]string
+			for _, value := range *list(&p) {
+				entries = append(entries, []string{value})
+			}
+			return entries
+		},
+		set: func(p *profile.Profile, entries [][]string) error {
+			values := []string{}
+			for _, entry := range entries {
+				values = append(values, strings.TrimSpace(entry[0]))
+			}
+			*list(p) = values
+			return nil
+		},
+	}
+}
+
+var pageSpecs = []pageSpec{
+	{
+		title:       "Target",
+		description: "Who the wordlist is for.",
+		groups: []groupSpec{{
+			name: "target",
+			fields: []fieldSpec{
+				{placeholder: "firstname (and secondname if you want)", field: profile.FieldFirstName},
+				{placeholder: "lastname", field: profile.FieldLastName},
+				{placeholder: "birthday (optional, DD/MM/YYYY or similar, use / to separate)", field: profile.FieldBirthday},
+			},
+			get: func(p profile.Profile) [][]string {
+				return [][]string{{p.FirstName, p.LastName, p.Birthday}}
+			},
+			set: func(p *profile.Profile, entries [][]string) error {
+				p.FirstName, p.LastName, p.Birthday = entries[0][0], entries[0][1], strings.TrimSpace(entries[0][2])
+				return nil
+			},
+		}},
+	},
+	{
+		title:       "Family and friends",
+		description: "Partners, children, parents and friends, with their birthdays if known.",
+		groups: []groupSpec{{
+			name:       "person",
+			repeatable: true,
+			fields: []fieldSpec{
+				{placeholder: "name", field: profile.FieldPeople},
+				{placeholder: "relation (optional, e.g. son)", field: profile.FieldPeople, part: "relation"},
+				{placeholder: "birthday (optional, DD/MM/YYYY)", field: profile.FieldPeople, part: "birthday"},
+			},
+			get: func(p profile.Profile) [][]string {
+				var entries [][]string
+				for _, person := range p.People {
+					entries = append(entries, []string{person.Name, person.Relation, person.Birthday})
+				}
+				return entries
+			},
+			set: func(p *profile.Profile, entries [][]string) error {
+				p.People = []profile.Person{}
+				for _, entry := range entries {
+					p.People = append(p.People, profile.Person{
+						Name:     strings.TrimSpace(entry[0]),
+						Relation: strings.TrimSpace(entry[1]),
+						Birthday: strings.TrimSpace(entry[2]),
+					})
+				}
+				return nil
+			},
+		}},
+	},
+	{
+		title:       "Dates",
+		description: "Other dates that matter, such as anniversaries.",
+		groups: []groupSpec{
+			listGroup("date", "date (DD/MM/YYYY or similar)", profile.FieldDates, func(p *profile.Profile) *[]string { return &p.Dates }),
+		},
+	},
+	{
+		title:       "Phone and work",
+		description: "Phone numbers, and companies or schools.",
+		groups: []groupSpec{
+			listGroup("phone number", "phone number", profile.FieldPhones, func(p *profile.Profile) *[]string { return &p.Phones }),
+			listGroup("company", "company or school", profile.FieldCompanies, func(p *profile.Profile) *[]string { return &p.Companies }),
+		},
+	},
+	{
+		title:       "Pets and places",
+		description: "Pets, and places such as home towns, streets or teams.",
+		groups: []groupSpec{
+			listGroup("pet", "pet name", profile.FieldPets, func(p *profile.Profile) *[]string { return &p.Pets }),
+			listGroup("place", "place", profile.FieldPlaces, func(p *profile.Profile) *[]string { return &p.Places }),
+		},
+	},
+	{
+		title:       "Other words",
+		description: "Anything else the target may use: hobbies, nicknames, brands...",
+		groups: []groupSpec{
+			listGroup("word", "related word", profile.FieldWords, func(p *profile.Profile) *[]string { return &p.Words }),
+		},
+	},
+	{
+		title:       "Variations",
+		description: "What to generate from the profile, and how.",
+		groups: []groupSpec{{
+			name: "variations",
+			fields: []fieldSpec{
+				{placeholder: "min password length (optional, default 6)", field: profile.FieldMinLength},
+				{placeholder: "max password length (optional, default 12)", field: profile.FieldMaxLength},
+				{placeholder: "output file path (optional, default ./wordlist.txt)"},
+			},
+			get: func(p profile.Profile) [][]string {
+				return [][]string{{
+					lengthValue(p.MinLength, profile.DefaultMinLength),
+					lengthValue(p.MaxLength, profile.DefaultMaxLength),
+					p.Output,
+				}}
+			},
+			set: func(p *profile.Profile, entries [][]string) error {
+				var err error
+				p.MinLength, err = profile.ParseLength(profile.FieldMinLength, entries[0][0], profile.DefaultMinLength)
+				if err != nil {
+					return err
+				}
+				p.MaxLength, err = profile.ParseLength(profile.FieldMaxLength, entries[0][1], profile.DefaultMaxLength)
+				if err != nil {
+					return err
+				}
+				p.Output = strings.TrimSpace(entries[0][2])
+				return nil
+			},
+		}},
+		transforms: true,
+	},
+}
+
+// group holds the inputs of a group, one row per entry.
+type group struct {
+	spec    *groupSpec
+	entries [][]textinput.Model
+}
+
+type page struct {
+	spec   *pageSpec
+	groups []*group
+}
+
+// fields returns the profile fields validated on the page.
+func (pg *page) fields() []string {
+	var fields []string
+	for _, g := range pg.groups {
+		for _, f := range g.spec.fields {
+			if f.field != "" && !slices.Contains(fields, f.field) {
+				fields = append(fields, f.field)
+			}
+		}
+	}
+	return fields
+}
+
+// values returns the entries typed into g, without the empty ones of a
+// repeatable group.
+func (g *group) values() [][]string {
+	var entries [][]string
+	for _, row := range g.entries {
+		entry := make([]string, len(row))
+		empty := true
+		for i, input := range row {
+			entry[i] = input.Value()
+			if strings.TrimSpace(entry[i]) != "" {
+				empty = false
+			}
+		}
+		if !empty || !g.spec.repeatable {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// entryOf returns the row of the index-th non-empty entry of a repeatable
+// group, as indexed in the profile.
+func (g *group) entryOf(index int) int {
+	for row := range g.entries {
+		if !g.spec.repeatable {
+			return row
+		}
+		empty := true
+		for _, input := range g.entries[row] {
+			if strings.TrimSpace(input.Value()) != "" {
+				empty = false
+			}
+		}
+		if !empty {
+			if index == 0 {
+				return row
+			}
+			index--
+		}
+	}
+	return 0
+}
+
+func (m *model) newInput(placeholder, value string) textinput.Model {
+	t := textinput.New()
+	t.PromptStyle = focusedStyle
+	t.PlaceholderStyle = placeholderStyle
+	t.Placeholder = placeholder
+	t.Width = m.inputWidth
+	t.SetValue(value)
+	return t
+}
+
+func (m *model) addEntry(g *group, values []string) {
+	row := make([]textinput.Model, len(g.spec.fields))
+	for i, f := range g.spec.fields {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		row[i] = m.newInput(f.placeholder, value)
+	}
+	g.entries = append(g.entries, row)
+}
+
+// setProfile fills the wizard with p. Fields the wizard has no input for
+// are kept, so generating from the wizard generates the profile.
+func (m *model) setProfile(p profile.Profile) {
+	m.base = p
+	m.pages = nil
+	for i := range pageSpecs {
+		pg := &page{spec: &pageSpecs[i]}
+		for j := range pg.spec.groups {
+			g := &group{spec: &pg.spec.groups[j]}
+			for _, entry := range g.spec.get(p) {
+				m.addEntry(g, entry)
+			}
+			if len(g.entries) == 0 {
+				m.addEntry(g, nil)
+			}
+			pg.groups = append(pg.groups, g)
+		}
+		m.pages = append(m.pages, pg)
+	}
+	m.setTransforms(p)
+}
+
+// formProfile returns the profile typed into the wizard over the last
+// profile loaded, without validating it.
+func (m *model) formProfile() (profile.Profile, error) {
+	p := m.base
+	// The panel lists the leet and caps variants among the transforms.
+	p.Leet, p.Caps = false, false
+	p.Transforms = m.transforms.specs()
+	p.Separators = m.transforms.separatorValues()
+	p.CombineDepth = m.transforms.depth
+	for _, pg := range m.pages {
+		for _, g := range pg.groups {
+			if err := g.spec.set(&p, g.values()); err != nil {
+				return p, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// profile returns the profile typed into the wizard, validated.
+func (m *model) profile() (profile.Profile, error) {
+	p, err := m.formProfile()
+	if err != nil {
+		return p, err
+	}
+	return p, p.Validate()
+}
+
+// validatePage validates the fields of page i only, so a page can be left
+// before the rest of the wizard is filled in.
+func (m *model) validatePage(i int) error {
+	pg := m.pages[i]
+	p, err := m.formProfile()
+	var fieldErr *profile.FieldError
+	if err != nil && errors.As(err, &fieldErr) && slices.Contains(pg.fields(), fieldErr.Field) {
+		return err
+	}
+	for _, field := range pg.fields() {
+		if err := p.ValidateField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type targetKind int
+
+const (
+	targetInput targetKind = iota
+	targetAdd
+	targetTransforms
+	targetBack
+	targetNext
+)
+
+// target is something on a page that can be focused.
+type target struct {
+	kind  targetKind
+	group int
+	entry int
+	field int
+}
+
+// reviewing reports whether the review step is shown.
+func (m *model) reviewing() bool {
+	return m.page == len(m.pages)
+}
+
+func (m *model) targets() []target {
+	var targets []target
+	if !m.reviewing() {
+		pg := m.pages[m.page]
+		for gi, g := range pg.groups {
+			for ei, row := range g.entries {
+				for fi := range row {
+					targets = append(targets, target{kind: targetInput, group: gi, entry: ei, field: fi})
+				}
+			}
+			if g.spec.repeatable {
+				targets = append(targets, target{kind: targetAdd, group: gi})
+			}
+		}
+		if pg.spec.transforms {
+			targets = append(targets, target{kind: targetTransforms})
+		}
+	}
+	if m.page > 0 {
+		targets = append(targets, target{kind: targetBack})
+	}
+	return append(targets, target{kind: targetNext})
+}
+
+func (m *model) focused() target {
+	targets := m.targets()
+	if m.focusIndex < 0 || m.focusIndex >= len(targets) {
+		return target{kind: targetNext}
+	}
+	return targets[m.focusIndex]
+}
+
+// focusedInput returns the input in focus, if any.
+func (m *model) focusedInput() *textinput.Model {
+	t := m.focused()
+	if t.kind != targetInput {
+		return nil
+	}
+	return &m.pages[m.page].groups[t.group].entries[t.entry][t.field]
+}
+
+// setFocus focuses target i of the page, wrapping around.
+func (m *model) setFocus(i int) tea.Cmd {
+	n := len(m.targets())
+	m.focusIndex = (i%n + n) % n
+	if !m.reviewing() {
+		for _, g := range m.pages[m.page].groups {
+			for _, row := range g.entries {
+				for i := range row {
+					row[i].Blur()
+				}
+			}
+		}
+	}
+	if input := m.focusedInput(); input != nil {
+		return input.Focus()
+	}
+	return nil
+}
+
+// focusTarget focuses the first target matching t.
+func (m *model) focusTarget(match func(t target) bool) tea.Cmd {
+	for i, t := range m.targets() {
+		if match(t) {
+			return m.setFocus(i)
+		}
+	}
+	return m.setFocus(0)
+}
+
+// showPage shows page i with its first target in focus.
+func (m *model) showPage(i int) tea.Cmd {
+	m.page = i
+	m.errMsg = ""
+	if m.reviewing() {
+		return m.focusTarget(func(t target) bool { return t.kind == targetNext })
+	}
+	return m.setFocus(0)
+}
+
+// showError shows err and focuses the input it is about, on whatever page
+// that is.
+func (m *model) showError(err error) tea.Cmd {
+	m.errMsg = err.Error()
+	var fieldErr *profile.FieldError
+	if !errors.As(err, &fieldErr) {
+		return nil
+	}
+	switch fieldErr.Field {
+	case profile.FieldTransforms, profile.FieldSeparators, profile.FieldCombineDepth:
+		return m.openTransforms(m.transformRowOf(fieldErr))
+	}
+	for pi, pg := range m.pages {
+		for gi, g := range pg.groups {
+			for fi, f := range g.spec.fields {
+				if f.field != fieldErr.Field || f.part != fieldErr.Part {
+					continue
+				}
+				m.page = pi
+				entry := g.entryOf(fieldErr.Index)
+				return m.focusTarget(func(t target) bool {
+					return t.kind == targetInput && t.group == gi && t.entry == entry && t.field == fi
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// nextPage validates the page and moves on to the next one.
+func (m *model) nextPage() tea.Cmd {
+	if m.reviewing() {
+		return nil
+	}
+	if err := m.validatePage(m.page); err != nil {
+		return m.showError(err)
+	}
+	return m.showPage(m.page + 1)
+}
+
+func (m *model) prevPage() tea.Cmd {
+	if m.page == 0 {
+		return nil
+	}
+	return m.showPage(m.page - 1)
+}
+
+// addAnother adds an entry to a repeatable group and focuses it.
+func (m *model) addAnother(gi int) tea.Cmd {
+	g := m.pages[m.page].groups[gi]
+	m.addEntry(g, nil)
+	entry := len(g.entries) - 1
+	return m.focusTarget(func(t target) bool {
+		return t.kind == targetInput && t.group == gi && t.entry == entry && t.field == 0
+	})
+}
+
+// removeFocusedEntry removes the entry in focus from a repeatable group,
+// keeping one empty entry at least.
+func (m *model) removeFocusedEntry() tea.Cmd {
+	t := m.focused()
+	if t.kind != targetInput {
+		return nil
+	}
+	g := m.pages[m.page].groups[t.group]
+	if !g.spec.repeatable {
+		return nil
+	}
+	g.entries = slices.Delete(g.entries, t.entry, t.entry+1)
+	if len(g.entries) == 0 {
+		m.addEntry(g, nil)
+	}
+	entry := min(t.entry, len(g.entries)-1)
+	return m.focusTarget(func(u target) bool {
+		return u.kind == targetInput && u.group == t.group && u.entry == entry && u.field == 0
+	})
+}
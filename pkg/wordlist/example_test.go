@@ -0,0 +1,65 @@
+package wordlist_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/efeaslansoyler/go-wordlistgen/pkg/wordlist"
+)
+
+func ExampleNew() {
+	_, err := wordlist.New(wordlist.WithFirstName("John"), wordlist.WithLength(0, 3))
+	fmt.Println(err)
+
+	_, err = wordlist.New(wordlist.WithLength(4, 8))
+	fmt.Println(err)
+	// Output:
+	// wordlist: WithLength(0, 3): lengths must be positive
+	// wordlist: no names, birthday, words or sources to generate from
+}
+
+func ExampleGenerator_All() {
+	g, err := wordlist.New(
+		wordlist.WithFirstName("John"),
+		wordlist.WithLastName("Doe"),
+		wordlist.WithLength(4, 8),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for c, err := range g.All(context.Background()) {
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(c.Password, c.Sources, c.Transforms)
+	}
+	// Output:
+	// John [John] []
+	// JohnDoe [John Doe] [combine]
+	// DoeJohn [Doe John] [combine]
+}
+
+func ExampleGenerator_Write() {
+	g, err := wordlist.New(
+		wordlist.WithFirstName("John"),
+		wordlist.WithLastName("Doe"),
+		wordlist.WithSeparators("_"),
+		wordlist.WithFormat(wordlist.FormatCSV),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := g.Write(context.Background(), os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// password,sources,transforms,score,strength,guesses_log10
+	// JohnDoe,John+Doe,combine,,2,7.00
+	// John_Doe,John+Doe,combine,,3,8.00
+	// DoeJohn,Doe+John,combine,,2,7.00
+	// Doe_John,Doe+John,combine,,3,8.00
+}
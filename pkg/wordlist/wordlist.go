@@ -0,0 +1,378 @@
+// Package wordlist generates password candidates from personal information,
+// the same way the go-wordlistgen command does, for use in other Go programs.
+//
+// A Generator is configured with functional options and produces its
+// candidates in a fixed order, either one at a time or written to an
+// io.Writer:
+//
+//	g, err := wordlist.New(
+//		wordlist.WithFirstName("John"),
+//		wordlist.WithLastName("Doe"),
+//		wordlist.WithBirthday(3, 7, 1991),
+//		wordlist.WithLeet(),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	for c, err := range g.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(c.Password)
+//	}
+//
+// Every method takes a context and stops generating once it is done.
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/efeaslansoyler/go-wordlistgen/internal/generator"
+	"github.com/efeaslansoyler/go-wordlistgen/internal/strength"
+)
+
+// Deduplication strategies, see WithDedup.
+const (
+	DedupMap   = generator.DedupMap
+	DedupSort  = generator.DedupSort
+	DedupBloom = generator.DedupBloom
+)
+
+// Output formats, see WithFormat.
+const (
+	FormatPlain = generator.FormatPlain
+	FormatJSONL = generator.FormatJSONL
+	FormatCSV   = generator.FormatCSV
+)
+
+// Generator generates the candidates of one profile. It holds no state
+// between runs and is safe for concurrent use.
+type Generator struct {
+	opts generator.Options
+	// err is the first invalid argument given to an option, reported by
+	// New.
+	err error
+}
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// New returns a Generator configured with opts. It needs at least one name,
+// birthday, word or source to build candidates from.
+func New(opts ...Option) (*Generator, error) {
+	g := &Generator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	o := g.opts
+	if len(o.InputFirstName)+len(o.InputLastName)+len(o.InputBirthday)+len(o.InputRelatedWords)+len(o.Sources) == 0 {
+		return nil, errors.New("wordlist: no names, birthday, words or sources to generate from")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("wordlist: %w", err)
+	}
+	return g, nil
+}
+
+// fail records err for New to return, unless an earlier option failed.
+func (g *Generator) fail(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// WithFirstName adds first (and middle) names.
+func WithFirstName(names ...string) Option {
+	return func(g *Generator) {
+		g.opts.InputFirstName = append(g.opts.InputFirstName, names...)
+	}
+}
+
+// WithLastName adds last names.
+func WithLastName(names ...string) Option {
+	return func(g *Generator) {
+		g.opts.InputLastName = append(g.opts.InputLastName, names...)
+	}
+}
+
+// WithBirthday sets the birthday.
+func WithBirthday(day, month, year int) Option {
+	return func(g *Generator) {
+		g.opts.InputBirthday = []string{
+			fmt.Sprintf("%02d", day),
+			fmt.Sprintf("%02d", month),
+			fmt.Sprintf("%04d", year),
+		}
+	}
+}
+
+// WithWords adds related words such as pets, places or hobbies.
+func WithWords(words ...string) Option {
+	return func(g *Generator) {
+		g.opts.InputRelatedWords = append(g.opts.InputRelatedWords, words...)
+	}
+}
+
+// WithSource adds the base words of the source registered under name, such
+// as WithSource("file", "pets.txt") or WithSource("numbers", "1990-2025").
+func WithSource(name string, args ...string) Option {
+	return func(g *Generator) {
+		g.opts.Sources = append(g.opts.Sources, generator.SourceSpec{Name: name, Args: args})
+	}
+}
+
+// WithLength only keeps candidates of min to max bytes. Both must be
+// positive, New fails otherwise. The default is 6 to 12.
+func WithLength(min, max int) Option {
+	return func(g *Generator) {
+		if min <= 0 || max <= 0 {
+			g.fail(fmt.Errorf("wordlist: WithLength(%d, %d): lengths must be positive", min, max))
+			return
+		}
+		g.opts.MinLength = min
+		g.opts.MaxLength = max
+	}
+}
+
+// WithLeet adds leet speak variants (a→4, e→3, i→1, o→0, s→5).
+func WithLeet() Option {
+	return func(g *Generator) {
+		g.opts.EnableLeet = true
+	}
+}
+
+// WithCaseVariants adds variants with the case of every letter swapped.
+func WithCaseVariants() Option {
+	return func(g *Generator) {
+		g.opts.EnableCapitalize = true
+	}
+}
+
+// WithTransforms applies the transforms registered under names, in order,
+// each to every candidate so far. Names may carry arguments for the
+// transforms that take some, as in "suffix:1,123" or "leet:a=@". Leet and
+// case variants enabled with their own options are applied after them.
+func WithTransforms(names ...string) Option {
+	return func(g *Generator) {
+		g.opts.Transforms = append(g.opts.Transforms, names...)
+	}
+}
+
+// WithSeparators also joins combined words with each of separators, e.g.
+// "_" for john_doe next to johndoe.
+func WithSeparators(separators ...string) Option {
+	return func(g *Generator) {
+		g.opts.Separators = append(g.opts.Separators, separators...)
+	}
+}
+
+// WithCombineDepth combines up to depth words into one candidate. The
+// default is 3.
+func WithCombineDepth(depth int) Option {
+	return func(g *Generator) {
+		g.opts.CombineDepth = depth
+	}
+}
+
+// WithTemplates adds the candidates of the built-in password structure
+// templates.
+func WithTemplates() Option {
+	return func(g *Generator) {
+		g.opts.EnableTemplates = true
+	}
+}
+
+// WithTemplateFile adds the candidates of the templates in a file, one per
+// line.
+func WithTemplateFile(path string) Option {
+	return func(g *Generator) {
+		g.opts.TemplateFilePath = path
+	}
+}
+
+// WithMarkovModel extends every base word with its suffixes most likely
+// according to a model trained with the train command.
+func WithMarkovModel(path string, suffixes int) Option {
+	return func(g *Generator) {
+		g.opts.MarkovModelPath = path
+		g.opts.MarkovSuffixes = suffixes
+	}
+}
+
+// WithMarkovRanking orders the candidates by Markov model likelihood, most
+// likely first. Nothing is produced before every candidate was scored.
+func WithMarkovRanking() Option {
+	return func(g *Generator) {
+		g.opts.MarkovRank = true
+	}
+}
+
+// WithMinStrength only keeps candidates with at least this estimated
+// strength, from 0 (too guessable) to 4 (very unguessable).
+func WithMinStrength(score int) Option {
+	return func(g *Generator) {
+		g.opts.MinStrength = score
+	}
+}
+
+// WithWorkers sets the number of parallel workers, by default the number of
+// CPUs. The output does not depend on it.
+func WithWorkers(n int) Option {
+	return func(g *Generator) {
+		g.opts.Workers = n
+	}
+}
+
+// WithDedup sets how duplicates are removed: DedupMap (the default),
+// DedupSort, which uses temporary files in tempDir and sorts the output, or
+// DedupBloom, which may drop up to bloomRate of the candidates.
+func WithDedup(strategy, tempDir string, bloomRate float64) Option {
+	return func(g *Generator) {
+		g.opts.Dedup = strategy
+		g.opts.TempDir = tempDir
+		g.opts.BloomFalsePositiveRate = bloomRate
+	}
+}
+
+// WithShard only produces shard index (1 based) of count disjoint shards.
+func WithShard(index, count int) Option {
+	return func(g *Generator) {
+		g.opts.ShardIndex = index
+		g.opts.ShardCount = count
+	}
+}
+
+// WithFormat sets the format Write writes in: FormatPlain (the default),
+// FormatJSONL or FormatCSV.
+func WithFormat(format string) Option {
+	return func(g *Generator) {
+		g.opts.Format = format
+	}
+}
+
+// Transform derives variants of a word. Registered transforms can be
+// selected by name with WithTransforms.
+type Transform interface {
+	// Name identifies the transform, it is recorded in the transforms of
+	// every candidate the transform made.
+	Name() string
+	Description() string
+	// Apply yields the variants of word.
+	Apply(word string) iter.Seq[string]
+}
+
+// RegisterTransform makes a transform available to every Generator by its
+// name. It panics if the name is empty or already taken, so it is meant to
+// be called from an init function.
+func RegisterTransform(t Transform) {
+	generator.RegisterTransform(t)
+}
+
+// Transforms returns every registered transform, sorted by name.
+func Transforms() []Transform {
+	var list []Transform
+	for _, t := range generator.Transforms() {
+		list = append(list, t)
+	}
+	return list
+}
+
+// Source produces base words, which candidates are combined from.
+// Registered sources can be selected by name with WithSource.
+type Source interface {
+	// Name identifies the source.
+	Name() string
+	Description() string
+	// Tokens returns the base words for the arguments given to WithSource.
+	Tokens(args []string) ([]string, error)
+}
+
+// RegisterSource makes a source available to every Generator by its name.
+// It panics if the name is empty or already taken, so it is meant to be
+// called from an init function.
+func RegisterSource(s Source) {
+	generator.RegisterSource(s)
+}
+
+// Sources returns every registered source, sorted by name.
+func Sources() []Source {
+	var list []Source
+	for _, s := range generator.Sources() {
+		list = append(list, s)
+	}
+	return list
+}
+
+// Candidate is a generated password and where it came from.
+type Candidate struct {
+	Password string
+	// Sources are the input tokens the password was built from.
+	Sources []string
+	// Transforms are the transforms applied to the sources, in order.
+	Transforms []string
+	// Score is the Markov model log likelihood, when a model is set.
+	Score *float64
+}
+
+// Strength returns the estimated strength of the password, from 0 (too
+// guessable) to 4 (very unguessable), and the log10 of the estimated number
+// of guesses needed to find it.
+func (c Candidate) Strength() (score int, guessesLog10 float64) {
+	r := strength.Estimate(c.Password)
+	return r.Score, r.Log10()
+}
+
+// Each calls fn for every candidate in order. If fn returns an error or ctx
+// is done, generation stops and Each returns that error.
+func (g *Generator) Each(ctx context.Context, fn func(Candidate) error) error {
+	return generator.EachCandidate(ctx, g.opts, func(c generator.Candidate) error {
+		return fn(Candidate(c))
+	})
+}
+
+var errStop = errors.New("stop")
+
+// All returns an iterator over the candidates. If generation fails the last
+// pair has the error.
+func (g *Generator) All(ctx context.Context) iter.Seq2[Candidate, error] {
+	return func(yield func(Candidate, error) bool) {
+		err := g.Each(ctx, func(c Candidate) error {
+			if !yield(c, nil) {
+				return errStop
+			}
+			return nil
+		})
+		if err != nil && err != errStop {
+			yield(Candidate{}, err)
+		}
+	}
+}
+
+// Write writes the candidates to w, one per line in the format set with
+// WithFormat.
+func (g *Generator) Write(ctx context.Context, w io.Writer) error {
+	return generator.WriteTo(ctx, g.opts, w)
+}
+
+// Size is the size of a wordlist.
+type Size struct {
+	Words int64
+	// Bytes is the size as plain text, one word per line.
+	Bytes int64
+}
+
+// Estimate generates the candidates without keeping them and returns their
+// number and size. Duplicates are removed with a bloom filter, so the count
+// may be slightly low.
+func (g *Generator) Estimate(ctx context.Context) (Size, error) {
+	size, err := generator.Estimate(ctx, g.opts)
+	return Size(size), err
+}